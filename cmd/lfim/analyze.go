@@ -0,0 +1,212 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+var analyzeCmd = &cobra.Command{
+	Use:   "analyze [id...]",
+	Short: "Analyze issues with Claude without the TUI",
+	Long: `Analyze the given issues headlessly, e.g. from cron, or with --all (alias
+--all-open) every open issue that has a brief body and no analysis yet. Each
+analysis is saved with its Claude session and moves the issue to analyzed, as in
+the TUI. By default Claude writes a markdown analysis; --json asks for the
+structured analysis (options to select from) that the TUI's [a] key produces.
+
+Issue IDs that are not open or already have an analysis are skipped unless
+--force is passed. Analyzing one again keeps its previous analysis as a version
+(browse them with V in the TUI's review) rather than overwriting it.
+
+Before starting, lfim prints how many issues will be analyzed with an estimate of
+the prompt tokens and cost (the average recorded cost per call, see lfim stats).
+Above ui.bulk_confirm_threshold issues the count must be typed to confirm, or
+--yes passed when stdin isn't a terminal.
+
+Issues are analyzed one at a time in ID order (--concurrency runs more at once),
+with progress printed per issue and a summary at the end. Exits non-zero if any
+analysis failed. Ctrl+C cancels the running analyses and saves nothing for them.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		all, _ := cmd.Flags().GetBool("all")
+		structured, _ := cmd.Flags().GetBool("json")
+		yes, _ := cmd.Flags().GetBool("yes")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		force, _ := cmd.Flags().GetBool("force")
+
+		if all == (len(args) > 0) {
+			return errors.New("pass issue IDs or --all")
+		}
+		if force && all {
+			return errors.New("--force only applies to issue IDs")
+		}
+		if concurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+
+		cfg, err := loadConfig(cmd, path)
+		if err != nil {
+			return err
+		}
+		s, notifier, err := openStorage(path, issuesDir(cmd))
+		if err != nil {
+			return err
+		}
+		defer notifier.Wait()
+
+		var issues []*model.Issue
+		if all {
+			idx, err := s.LoadIndex()
+			if err != nil {
+				return err
+			}
+			for _, entry := range idx.Issues {
+				if entry.Status != model.StatusOpen || s.AnalysisJSONExists(entry.ID) || s.AnalysisExists(entry.ID) {
+					continue
+				}
+				issue, err := s.LoadBrief(entry.ID)
+				if err != nil || issue == nil || strings.TrimSpace(issue.Content) == "" {
+					continue
+				}
+				issues = append(issues, issue)
+			}
+		} else {
+			for _, id := range args {
+				issue, err := s.LoadBrief(id)
+				if err != nil {
+					return err
+				}
+				if issue == nil {
+					return fmt.Errorf("issue %s not found", id)
+				}
+				if !force {
+					if issue.Status != model.StatusOpen {
+						fmt.Printf("Skipping %s: %s (--force to analyze anyway)\n", id, issue.Status)
+						continue
+					}
+					if s.AnalysisJSONExists(id) || s.AnalysisExists(id) {
+						fmt.Printf("Skipping %s: already analyzed (--force to analyze again)\n", id)
+						continue
+					}
+				}
+				issues = append(issues, issue)
+			}
+		}
+		if len(issues) == 0 {
+			fmt.Println("No open issues without an analysis")
+			return nil
+		}
+
+		c := claude.New(s.ProjectRoot)
+		c.Executable = cfg.Claude.Command
+		c.ExtraArgs = cfg.Claude.ExtraArgs
+		c.ReadOnly = cfg.Claude.ReadOnly
+		c.Timeout = cfg.Claude.Timeout
+		c.SetMaxConcurrent(0) // w.slots limits analyses to --concurrency
+
+		w := &issueWatcher{
+			storage:  s,
+			claude:   c,
+			guidance: cfg.Claude.LabelGuidance,
+			model:    cfg.Claude.ModelFor("analyze"),
+			text:     !structured,
+			slots:    make(chan struct{}, concurrency),
+			logger:   log.New(os.Stdout, "", log.LstdFlags),
+		}
+
+		prompts := make([]string, len(issues))
+		for i, issue := range issues {
+			prompts[i] = w.prompt(issue)
+		}
+		history, err := s.TotalUsage()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Analyzing %d issues: %s\n", len(issues), claude.EstimateCalls(prompts, history))
+
+		if threshold := cfg.UI.BulkConfirmThreshold; threshold >= 0 && len(issues) > threshold && !yes {
+			if !confirmCount(len(issues)) {
+				return fmt.Errorf("not confirmed: analyzing more than %d issues (ui.bulk_confirm_threshold) needs the count typed or --yes", threshold)
+			}
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		// Start analyses in order as slots free up, so one at a time means ID order
+		var mu sync.Mutex
+		var failed []string
+		for i, issue := range issues {
+			if !w.acquire(ctx) {
+				mu.Lock()
+				for _, skipped := range issues[i:] {
+					failed = append(failed, skipped.ID)
+				}
+				mu.Unlock()
+				break
+			}
+			w.logger.Printf("[%d/%d] %s", i+1, len(issues), issue.ID)
+			w.running.Add(1)
+			go func() {
+				defer w.running.Done()
+				defer w.release()
+				if !w.analyze(ctx, issue) {
+					mu.Lock()
+					failed = append(failed, issue.ID)
+					mu.Unlock()
+				}
+			}()
+		}
+		w.running.Wait()
+
+		fmt.Printf("Analyzed %d of %d issues\n", len(issues)-len(failed), len(issues))
+		if len(failed) > 0 {
+			slices.Sort(failed)
+			return fmt.Errorf("%d analyses failed or were cancelled: %s", len(failed), strings.Join(failed, ", "))
+		}
+		return nil
+	},
+}
+
+// confirmCount asks for n to be typed on the terminal. Without a terminal to ask
+// on, it returns false.
+func confirmCount(n int) bool {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return false
+	}
+	fmt.Printf("Type %d to analyze %d issues: ", n, n)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	return strings.TrimSpace(answer) == strconv.Itoa(n)
+}
+
+func init() {
+	analyzeCmd.Flags().Bool("all", false, "Analyze every open issue that has no analysis yet")
+	analyzeCmd.Flags().Bool("json", false, "Ask for a structured analysis with options, as the TUI does")
+	analyzeCmd.Flags().Bool("force", false, "Analyze the given issues even if closed or already analyzed")
+	analyzeCmd.Flags().Bool("yes", false, "Skip the confirmation above ui.bulk_confirm_threshold")
+	analyzeCmd.Flags().Int("concurrency", 1, "Maximum analyses running at once")
+	analyzeCmd.Flags().SetNormalizeFunc(func(f *pflag.FlagSet, name string) pflag.NormalizedName {
+		if name == "all-open" {
+			name = "all"
+		}
+		return pflag.NormalizedName(name)
+	})
+	rootCmd.AddCommand(analyzeCmd)
+}
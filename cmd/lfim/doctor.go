@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the issues directory for problems",
+	Long: `Check the issues directory for problems: index.yaml drift from the issue
+directories, malformed or invalid briefs, broken duplicate_of references,
+analyses older than their brief, and orphaned Claude sessions.
+
+--fix applies the safe repairs: sync index.yaml entries with their briefs,
+remove entries whose directory is gone, default missing statuses to open and
+remove orphaned sessions (an unreadable index.yaml is rebuilt from the briefs).
+Issue directories missing from the index are added after asking, or without
+asking when stdin isn't a terminal. Nothing is changed without --fix. Exits
+non-zero while errors remain.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		fix, _ := cmd.Flags().GetBool("fix")
+
+		s := storage.New(path, issuesDir(cmd))
+		if _, err := os.Stat(s.IssuesDir); err != nil {
+			return fmt.Errorf("no issues directory at %s", s.IssuesDir)
+		}
+
+		problems, err := s.Diagnose()
+		if err != nil {
+			return err
+		}
+
+		if fix && len(problems) > 0 {
+			fixed, err := s.Repair(confirmIndexAdditions(problems))
+			for _, f := range fixed {
+				fmt.Printf("fixed: %s\n", f)
+			}
+			if err != nil {
+				return fmt.Errorf("repair failed: %w", err)
+			}
+			if problems, err = s.Diagnose(); err != nil {
+				return err
+			}
+		}
+
+		if len(problems) == 0 {
+			fmt.Println("No problems found")
+			return nil
+		}
+
+		errorCount := printProblems(problems, fix)
+		if errorCount > 0 {
+			return fmt.Errorf("%d error(s) remain", errorCount)
+		}
+		return nil
+	},
+}
+
+// confirmIndexAdditions asks before each unindexed directory is added to index.yaml,
+// dropping the ones declined. Without a terminal to ask on, all are kept.
+func confirmIndexAdditions(problems []storage.Problem) []storage.Problem {
+	info, err := os.Stdin.Stat()
+	if err != nil || info.Mode()&os.ModeCharDevice == 0 {
+		return problems
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	var confirmed []storage.Problem
+	for _, p := range problems {
+		if p.AddsToIndex() {
+			fmt.Printf("%s is not in index.yaml. Add it? [y/N] ", p.IssueID)
+			answer, _ := reader.ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				continue
+			}
+		}
+		confirmed = append(confirmed, p)
+	}
+	return confirmed
+}
+
+// printProblems prints problems grouped by severity and returns the error count
+func printProblems(problems []storage.Problem, fixed bool) int {
+	errorCount := 0
+	for _, severity := range []storage.Severity{storage.SeverityError, storage.SeverityWarning} {
+		var group []storage.Problem
+		for _, p := range problems {
+			if p.Severity == severity {
+				group = append(group, p)
+			}
+		}
+		if len(group) == 0 {
+			continue
+		}
+		if severity == storage.SeverityError {
+			errorCount = len(group)
+		}
+
+		fmt.Printf("%ss (%d):\n", severity, len(group))
+		for _, p := range group {
+			id := p.IssueID
+			if id == "" {
+				id = "index"
+			}
+			hint := ""
+			if p.Fixable() && !fixed {
+				hint = "  [--fix]"
+			}
+			fmt.Printf("  %-6s %s%s\n", id, p.Message, hint)
+		}
+	}
+	return errorCount
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix", false, "Apply safe automatic repairs")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Write every issue and its files as JSON",
+	Long: `Write every indexed issue to stdout (or --output) as a single JSON document.
+
+The export is lossless: it holds each issue's index metadata (ID, title, type,
+status, priority, labels, blocks, blocked_by, created, updated and closed dates,
+discard reason, duplicate-of) and the exact content of every file in its
+directory, including analyses, plans, their versions, feedback and tasks.
+Runtime files (the Claude session, partial output, rejected reviews and
+.claude.log) are left out. "lfim import" recreates the issues from it, which
+makes it suitable for backups and for moving issues between repositories.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		output, _ := cmd.Flags().GetString("output")
+
+		s := storage.New(path, issuesDir(cmd))
+		if _, err := os.Stat(s.IssuesDir); err != nil {
+			return fmt.Errorf("no issues directory at %s", s.IssuesDir)
+		}
+
+		var w io.Writer = os.Stdout
+		if output != "" {
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			w = f
+		}
+		return s.Export(w)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringP("output", "o", "", "Write to this file instead of stdout")
+	rootCmd.AddCommand(exportCmd)
+}
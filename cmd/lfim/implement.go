@@ -0,0 +1,156 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var implementCmd = &cobra.Command{
+	Use:   "implement <issue-id>",
+	Short: "Implement a planned issue by resuming its Claude session",
+	Long: `Implement a planned issue by resuming its Claude session with the plan.
+Without a session (e.g. it was cleared), a fresh session starts with the brief
+and plan as context instead.
+
+By default Claude runs interactively in this terminal, as in the TUI.
+With --headless Claude runs in print mode and edits files without prompting,
+which is suitable for unattended runs. Headless mode MODIFIES CODE and
+requires --yes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		headless, _ := cmd.Flags().GetBool("headless")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if headless && !yes {
+			return errors.New("headless implement modifies code without prompting; pass --yes to confirm")
+		}
+
+		s, notifier, err := openStorage(path, issuesDir(cmd))
+		if err != nil {
+			return err
+		}
+		defer notifier.Wait()
+		cfg, err := loadConfig(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		issue, sessionID, err := loadImplementable(s, args[0])
+		if err != nil {
+			return err
+		}
+
+		if cfg.Implement.CreateBranch {
+			branch, err := s.CreateIssueBranch(issue.ID, issue.Title)
+			if err != nil {
+				return err
+			}
+			if branch != "" {
+				fmt.Fprintf(os.Stderr, "Switched to %s\n", branch)
+			}
+		}
+
+		// Stage confirmed brief/analysis/plan as the baseline so the later diff is code-only
+		s.StageIssueFiles(issue.ID)
+
+		prompt := claude.BuildImplementPrompt(s.PlanPath(issue.ID))
+		if sessionID == "" {
+			fmt.Fprintf(os.Stderr, "No Claude session to resume for %s; starting a fresh session with the brief and plan as context\n", issue.ID)
+			if prompt, err = freshImplementPrompt(s, issue.ID); err != nil {
+				return err
+			}
+		}
+		c := claude.New(s.ProjectRoot)
+		c.Executable = cfg.Claude.Command
+		c.ExtraArgs = cfg.Claude.ExtraArgs
+
+		if headless {
+			fmt.Fprintf(os.Stderr, "Implementing %s headless (this may take a while)...\n", issue.ID)
+			result := c.RunImplement(prompt, sessionID)
+			if !result.Usage.IsZero() {
+				_ = s.AddUsage(issue.ID, result.Usage)
+			}
+			if !result.Success {
+				return fmt.Errorf("implement %s failed: %s", issue.ID, strings.TrimSpace(result.Result))
+			}
+			fmt.Println(strings.TrimSpace(result.Result))
+		} else {
+			args := []string{prompt}
+			if sessionID != "" {
+				args = []string{"--resume", sessionID, prompt}
+			}
+			claudeCmd := c.Command(args...)
+			claudeCmd.Stdin = os.Stdin
+			claudeCmd.Stdout = os.Stdout
+			claudeCmd.Stderr = os.Stderr
+			if err := claudeCmd.Run(); err != nil {
+				return fmt.Errorf("running %s: %w", c.Executable, err)
+			}
+		}
+
+		if err := s.UpdateIssueStatus(issue.ID, model.StatusImplemented, ""); err != nil {
+			return err
+		}
+
+		fmt.Fprintf(os.Stderr, "Implemented %s\n", issue.ID)
+		if stat := s.GitDiffStat(); stat != "" {
+			fmt.Fprint(os.Stderr, stat)
+		}
+		return nil
+	},
+}
+
+// loadImplementable returns the issue and its session ID (empty if there is none),
+// applying the same preconditions as the TUI implement action
+func loadImplementable(s *storage.Storage, issueID string) (*model.Issue, string, error) {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return nil, "", fmt.Errorf("loading index: %w", err)
+	}
+	issue := idx.GetIssue(issueID)
+	if issue == nil {
+		return nil, "", fmt.Errorf("issue %s not found", issueID)
+	}
+	if issue.Status != model.StatusPlanned {
+		return nil, "", fmt.Errorf("only planned issues can be implemented (%s is %s)", issue.ID, issue.Status)
+	}
+	if !s.PlanExists(issue.ID) {
+		return nil, "", fmt.Errorf("%s has no plan.md", issue.ID)
+	}
+	if blockers := idx.Blockers(issue.ID); len(blockers) > 0 {
+		return nil, "", fmt.Errorf("%s is blocked by %s; close that first", issue.ID, strings.Join(blockers, ", "))
+	}
+	sessionID, _ := s.LoadSessionID(issue.ID)
+	return issue, sessionID, nil
+}
+
+// freshImplementPrompt builds the prompt for implementing without a session to resume
+func freshImplementPrompt(s *storage.Storage, issueID string) (string, error) {
+	brief, err := s.LoadBrief(issueID)
+	if err != nil {
+		return "", err
+	}
+	if brief == nil {
+		return "", fmt.Errorf("%s has no brief.md", issueID)
+	}
+	plan, err := s.LoadPlan(issueID)
+	if err != nil {
+		return "", err
+	}
+	return claude.BuildFreshImplementPrompt(brief.Content, plan, s.PlanPath(issueID)), nil
+}
+
+func init() {
+	implementCmd.Flags().Bool("headless", false, "Run Claude non-interactively in print mode (modifies code)")
+	implementCmd.Flags().Bool("yes", false, "Confirm headless implementation")
+	rootCmd.AddCommand(implementCmd)
+}
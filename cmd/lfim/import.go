@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Recreate issues from an export",
+	Long: `Recreate the issues of an "lfim export" document, read from the file
+argument or stdin, with their original IDs and files.
+
+Nothing is written if any exported ID is already in the index or has an issue
+directory, so import into a fresh issues directory (see --issues-dir) or
+one without those IDs. Imported files are staged.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+
+		var r io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			f, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+			r = f
+		}
+
+		s := storage.New(path, issuesDir(cmd))
+		imported, err := s.Import(r)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]string, len(imported))
+		for i, issue := range imported {
+			ids[i] = issue.ID
+		}
+		fmt.Printf("Imported %d issues", len(imported))
+		if len(ids) > 0 {
+			fmt.Printf(" (%s)", strings.Join(ids, ", "))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(importCmd)
+}
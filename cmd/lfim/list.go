@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+	"github.com/lunit-heesungyang/issue-manager/internal/tui"
+	"github.com/lunit-heesungyang/issue-manager/internal/ui"
+)
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Print issues without launching the TUI",
+	Long: `Print issues as a table of ID, type, status, priority, title and age (time
+since creation), colored by status on a terminal unless NO_COLOR is set.
+
+Filter with --status (repeatable or comma-separated) and --type, and by the
+artifacts on disk with --has-analysis/--no-analysis and --has-plan/--no-plan,
+e.g. "--status open --has-analysis" finds analyses the index doesn't reflect.
+--json prints the filtered issues as JSON for scripting. Fails if the issues
+directory doesn't exist.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		statusNames, _ := cmd.Flags().GetStringSlice("status")
+		typeName, _ := cmd.Flags().GetString("type")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		analysisFilter, err := presenceFlag(cmd, "analysis")
+		if err != nil {
+			return err
+		}
+		planFilter, err := presenceFlag(cmd, "plan")
+		if err != nil {
+			return err
+		}
+
+		statuses, err := parseStatuses(statusNames)
+		if err != nil {
+			return err
+		}
+
+		switch model.IssueType(typeName) {
+		case "", model.TypeFeature, model.TypeBug, model.TypeRefactor:
+		default:
+			return fmt.Errorf("invalid type %q (expected feature, bug or refactor)", typeName)
+		}
+
+		s := storage.New(path, issuesDir(cmd))
+		if _, err := os.Stat(s.IssuesDir); err != nil {
+			return fmt.Errorf("no issues directory at %s", s.IssuesDir)
+		}
+
+		idx, err := s.LoadIndex()
+		if err != nil {
+			return err
+		}
+
+		issues := idx.Issues
+		if len(statuses) > 0 {
+			issues = idx.FilterByStatus(statuses...)
+		}
+		if typeName != "" {
+			var filtered []*model.Issue
+			for _, issue := range issues {
+				if issue.Type == model.IssueType(typeName) {
+					filtered = append(filtered, issue)
+				}
+			}
+			issues = filtered
+		}
+
+		if analysisFilter != nil || planFilter != nil {
+			var filtered []*model.Issue
+			for _, issue := range issues {
+				if analysisFilter != nil && s.HasAnalysis(issue.ID) != *analysisFilter {
+					continue
+				}
+				if planFilter != nil && s.PlanExists(issue.ID) != *planFilter {
+					continue
+				}
+				filtered = append(filtered, issue)
+			}
+			issues = filtered
+		}
+
+		if asJSON {
+			if issues == nil {
+				issues = []*model.Issue{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(issues)
+		}
+
+		now := time.Now()
+		rows := make([][]string, len(issues))
+		for i, issue := range issues {
+			rows[i] = []string{issue.ID, string(issue.Type), string(issue.Status), string(issue.Priority), issue.Title, formatAge(issue.Created, now)}
+		}
+
+		var style func(row, col int) lipgloss.Style
+		if colorOutput() {
+			styles := tui.DefaultStyles()
+			style = func(row, col int) lipgloss.Style {
+				switch {
+				case row < 0:
+					return lipgloss.NewStyle().Bold(true)
+				case col == listStatusColumn:
+					return styles.StatusStyle(issues[row].Status)
+				case col == listAgeColumn:
+					return lipgloss.NewStyle().Foreground(ui.ColorMuted)
+				}
+				return lipgloss.NewStyle()
+			}
+		}
+		return writeTable(os.Stdout, []string{"ID", "TYPE", "STATUS", "PRIORITY", "TITLE", "AGE"}, rows, style)
+	},
+}
+
+// Columns of the list table that are colored
+const (
+	listStatusColumn = 2
+	listAgeColumn    = 5
+)
+
+// presenceFlag reads the --has-<artifact>/--no-<artifact> pair: nil when neither is set
+func presenceFlag(cmd *cobra.Command, artifact string) (*bool, error) {
+	has, _ := cmd.Flags().GetBool("has-" + artifact)
+	no, _ := cmd.Flags().GetBool("no-" + artifact)
+	switch {
+	case has && no:
+		return nil, fmt.Errorf("--has-%s and --no-%s are mutually exclusive", artifact, artifact)
+	case has || no:
+		return &has, nil
+	}
+	return nil, nil
+}
+
+// parseStatuses validates status names against the known lifecycle statuses
+func parseStatuses(names []string) ([]model.IssueStatus, error) {
+	var statuses []model.IssueStatus
+	for _, name := range names {
+		status := model.IssueStatus(name)
+		switch status {
+		case model.StatusOpen, model.StatusAnalyzed, model.StatusPlanned,
+			model.StatusImplemented, model.StatusClosed, model.StatusInvalid:
+			statuses = append(statuses, status)
+		default:
+			return nil, fmt.Errorf("invalid status %q (expected open, analyzed, planned, implemented, closed or invalid)", name)
+		}
+	}
+	return statuses, nil
+}
+
+func init() {
+	listCmd.Flags().StringSlice("status", nil, "Only show issues with these statuses")
+	listCmd.Flags().StringP("type", "t", "", "Only show issues of this type (feature, bug, refactor)")
+	listCmd.Flags().Bool("has-analysis", false, "Only show issues with an analysis on disk")
+	listCmd.Flags().Bool("no-analysis", false, "Only show issues without an analysis on disk")
+	listCmd.Flags().Bool("has-plan", false, "Only show issues with a plan on disk")
+	listCmd.Flags().Bool("no-plan", false, "Only show issues without a plan on disk")
+	listCmd.Flags().Bool("json", false, "Print issues as JSON")
+	rootCmd.AddCommand(listCmd)
+}
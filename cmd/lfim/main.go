@@ -7,7 +7,11 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/lunit-heesungyang/issue-manager/internal/config"
+	"github.com/lunit-heesungyang/issue-manager/internal/notify"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
 	"github.com/lunit-heesungyang/issue-manager/internal/tui"
+	"github.com/lunit-heesungyang/issue-manager/internal/ui"
 )
 
 var rootCmd = &cobra.Command{
@@ -22,22 +26,111 @@ Features:
   - Create and manage issues with a TUI interface
   - AI-powered issue analysis and planning via Claude
   - Git integration for automatic staging
-  - Filter issues by status (Active/All/Closed)`,
+  - Filter issues by status (Active/Implemented/All/Closed)`,
+	// main prints errors; don't dump usage for runtime failures
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		path, _ := cmd.Flags().GetString("path")
+		timings, _ := cmd.Flags().GetBool("timings")
+		inline, _ := cmd.Flags().GetBool("inline")
+		noAltScreen, _ := cmd.Flags().GetBool("no-alt-screen")
+		ascii, _ := cmd.Flags().GetBool("ascii")
+		editor, _ := cmd.Flags().GetString("editor")
 
-		model := tui.New(path)
-		p := tea.NewProgram(model, tea.WithAltScreen())
+		cfg, err := loadConfig(cmd, path)
+		if err != nil {
+			return err
+		}
+
+		model := tui.New(path, issuesDir(cmd), cfg)
+		if ascii || ui.DetectASCII() {
+			model = model.WithASCII()
+		}
+		if editor != "" {
+			model = model.WithEditor(editor)
+		}
+		var opts []tea.ProgramOption
+		if inline || noAltScreen {
+			model = model.WithInline()
+		} else {
+			opts = append(opts, tea.WithAltScreen())
+		}
+		p := tea.NewProgram(model, opts...)
 
-		if _, err := p.Run(); err != nil {
+		finalModel, err := p.Run()
+		if m, ok := finalModel.(tui.Model); ok {
+			defer m.Cleanup()
+		}
+		if err != nil {
 			return fmt.Errorf("running TUI: %w", err)
 		}
+
+		if timings {
+			if m, ok := finalModel.(tui.Model); ok {
+				if summary := m.Timings(); summary != "" {
+					fmt.Fprint(os.Stderr, summary)
+				} else {
+					fmt.Fprintln(os.Stderr, "No Claude calls recorded")
+				}
+			}
+		}
 		return nil
 	},
 }
 
+// openStorage opens the project's storage with the configured status hooks attached.
+// Callers defer the notifier's Wait so hooks finish before the process exits.
+func openStorage(path, issuesDir string) (*storage.Storage, *notify.Notifier, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	s := storage.New(path, issuesDir)
+	s.MaxAnalysisVersions = cfg.MaxAnalysisVersions
+	return s, notify.Attach(s, cfg.Hooks), nil
+}
+
+// loadConfig loads the config and applies the --model-analyze and --model-plan overrides
+func loadConfig(cmd *cobra.Command, path string) (*config.Config, error) {
+	cfg, err := config.Load(path)
+	if err != nil {
+		return nil, err
+	}
+	for task, flag := range map[string]string{"analyze": "model-analyze", "plan": "model-plan"} {
+		if model, _ := cmd.Flags().GetString(flag); model != "" {
+			if cfg.Claude.Models == nil {
+				cfg.Claude.Models = make(map[string]string)
+			}
+			cfg.Claude.Models[task] = model
+		}
+	}
+	return cfg, nil
+}
+
+// issuesDirEnv names the environment variable that sets the issues directory when
+// --issues-dir isn't given
+const issuesDirEnv = "LFIM_ISSUES_DIR"
+
+// issuesDir returns the issues directory from --issues-dir or $LFIM_ISSUES_DIR;
+// empty means storage.DefaultIssuesDir
+func issuesDir(cmd *cobra.Command) string {
+	if dir, _ := cmd.Flags().GetString("issues-dir"); dir != "" {
+		return dir
+	}
+	return os.Getenv(issuesDirEnv)
+}
+
 func init() {
-	rootCmd.Flags().StringP("path", "p", "", "Project root path (default: current directory)")
+	rootCmd.PersistentFlags().StringP("path", "p", "", "Project root path (default: current directory)")
+	rootCmd.PersistentFlags().String("issues-dir", "", "Issues directory, relative to the project root (default: issues, or $"+issuesDirEnv+")")
+	rootCmd.PersistentFlags().String("model-analyze", "", "Claude model for analyses (overrides claude.models.analyze)")
+	rootCmd.PersistentFlags().String("model-plan", "", "Claude model for plans (overrides claude.models.plan)")
+	rootCmd.Flags().Bool("timings", false, "Print Claude call timings on exit")
+	rootCmd.Flags().Bool("inline", false, "Run without the alternate screen so output stays in scrollback")
+	rootCmd.Flags().Bool("no-alt-screen", false, "Alias for --inline")
+	rootCmd.Flags().String("editor", "", "Editor for briefs, analyses and plans, with arguments (overrides $EDITOR)")
+	rootCmd.Flags().Bool("ascii", false, "Draw ASCII instead of Unicode icons and borders (auto-detected from TERM and locale)")
 }
 
 func main() {
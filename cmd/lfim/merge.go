@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge <keep-id> <merge-id>",
+	Short: "Merge a duplicate issue into a canonical one",
+	Long: `Merge a duplicate issue into a canonical one.
+
+The merged issue's brief is appended to the canonical brief under a
+"Merged from" section, and the merged issue is closed with duplicate_of
+pointing at the canonical issue. Affected files are staged.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		s, notifier, err := openStorage(path, issuesDir(cmd))
+		if err != nil {
+			return err
+		}
+		defer notifier.Wait()
+
+		if err := s.MergeIssues(args[0], args[1]); err != nil {
+			return err
+		}
+		fmt.Printf("Merged %s into %s\n", args[1], args[0])
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+}
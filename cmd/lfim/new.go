@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+var newCmd = &cobra.Command{
+	Use:   "new [title]",
+	Short: "Create a new issue",
+	Long: `Create a new issue and print its ID to stdout.
+
+The title comes from --title or the arguments. The brief body is taken from
+--body, from stdin with --body -, or without --body from a file redirected to
+stdin; otherwise it is seeded from --template (issues/.templates/<name>.md) or the
+type's default template (issues/.templates/<type>.md). An empty body is allowed.
+
+A pipe on stdin is only read with --body -, so git hooks and scripts whose stdin
+is a pipe they don't control neither block nor turn it into the brief.
+
+  id=$(lfim new --title "Flaky login test" --type bug < notes.md)
+  id=$(git log -1 --format=%B | lfim new --title "Follow up" --body -)`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		title, _ := cmd.Flags().GetString("title")
+		typeName, _ := cmd.Flags().GetString("type")
+		templateName, _ := cmd.Flags().GetString("template")
+		body, _ := cmd.Flags().GetString("body")
+		priorityName, _ := cmd.Flags().GetString("priority")
+
+		if title == "" {
+			title = strings.Join(args, " ")
+		}
+		title = strings.TrimSpace(title)
+		if title == "" {
+			return errors.New("a title is required (--title or argument)")
+		}
+
+		issueType := model.IssueType(typeName)
+		switch issueType {
+		case model.TypeFeature, model.TypeBug, model.TypeRefactor:
+		default:
+			return fmt.Errorf("invalid type %q (expected feature, bug or refactor)", typeName)
+		}
+		priority := model.IssuePriority(priorityName)
+		if !priority.Valid() {
+			return fmt.Errorf("invalid priority %q (expected low, medium, high or critical)", priorityName)
+		}
+
+		s, notifier, err := openStorage(path, issuesDir(cmd))
+		if err != nil {
+			return err
+		}
+		defer notifier.Wait()
+
+		if err := s.EnsureIssuesDir(); err != nil {
+			return err
+		}
+
+		switch {
+		case body == "-":
+			if body, err = readStdin(); err != nil {
+				return err
+			}
+		case body == "" && stdinIsFile():
+			if body, err = readStdin(); err != nil {
+				return err
+			}
+		}
+		if body == "" {
+			body, err = s.TemplateBody(templateName, issueType)
+			if err != nil {
+				if names, _ := s.ListTemplates(); len(names) > 0 {
+					return fmt.Errorf("%w (available: %s)", err, strings.Join(names, ", "))
+				}
+				return err
+			}
+		}
+
+		issue, err := s.CreateIssue(title, issueType, priority, strings.TrimSpace(body))
+		if err != nil {
+			return err
+		}
+		// Only the ID, so scripts can capture it
+		fmt.Println(issue.ID)
+		return nil
+	},
+}
+
+// stdinIsFile reports whether stdin is a regular file, e.g. a < redirect, which
+// can be read without blocking
+func stdinIsFile() bool {
+	info, err := os.Stdin.Stat()
+	return err == nil && info.Mode().IsRegular()
+}
+
+// readStdin returns stdin's content
+func readStdin() (string, error) {
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", fmt.Errorf("reading stdin: %w", err)
+	}
+	return string(data), nil
+}
+
+func init() {
+	newCmd.Flags().String("title", "", "Issue title (alternative to the positional argument)")
+	newCmd.Flags().StringP("body", "b", "", "Brief body; - reads it from stdin (default: stdin if a file is redirected to it)")
+	newCmd.Flags().StringP("type", "t", string(model.TypeFeature), "Issue type (feature, bug, refactor)")
+	newCmd.Flags().String("template", "", "Named template from issues/.templates")
+	newCmd.Flags().String("priority", string(model.PriorityMedium), "Issue priority (low, medium, high, critical)")
+	rootCmd.AddCommand(newCmd)
+}
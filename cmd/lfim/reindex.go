@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var reindexCmd = &cobra.Command{
+	Use:   "reindex",
+	Short: "Rebuild index.yaml from the issue directories",
+	Long: `Rebuild index.yaml from the issue directories.
+
+Every issues/<id>/ directory with a readable brief.md is indexed under its
+directory name, with title, type, status, priority and labels taken from the
+brief's frontmatter. Directories without a valid brief are reported and
+skipped. The old index only contributes creation dates.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+
+		s := storage.New(path, issuesDir(cmd))
+		if _, err := os.Stat(s.IssuesDir); err != nil {
+			return fmt.Errorf("no issues directory at %s", s.IssuesDir)
+		}
+
+		ids, err := s.ListIssueIDs()
+		if err != nil {
+			return err
+		}
+		idx, err := s.RebuildIndex()
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if idx.GetIssue(id) != nil {
+				continue
+			}
+			reason := "no brief.md"
+			if _, err := s.LoadBrief(id); err != nil {
+				reason = err.Error()
+			}
+			fmt.Fprintf(os.Stderr, "warning: skipped %s: %s\n", id, reason)
+		}
+		fmt.Printf("Indexed %d issues\n", len(idx.Issues))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(reindexCmd)
+}
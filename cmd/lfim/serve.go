@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve issues as read-only JSON over HTTP",
+	Long: `Serve issues as read-only JSON over HTTP:
+
+  GET /issues                 index entries of every issue
+  GET /issues/{id}            one issue with all its files, as in "lfim export"
+  GET /issues/{id}/analysis   the analysis: markdown, and the structured form if any
+
+The server binds to localhost unless --host says otherwise, has no
+authentication, and never modifies issues. Stop it with Ctrl+C.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		host, _ := cmd.Flags().GetString("host")
+		port, _ := cmd.Flags().GetInt("port")
+
+		s := storage.New(path, issuesDir(cmd))
+		if _, err := os.Stat(s.IssuesDir); err != nil {
+			return fmt.Errorf("no issues directory at %s", s.IssuesDir)
+		}
+
+		srv := &http.Server{
+			Addr:              net.JoinHostPort(host, strconv.Itoa(port)),
+			Handler:           issuesHandler(s),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(shutdownCtx)
+		}()
+
+		log.Printf("Serving %s on http://%s", s.IssuesDir, srv.Addr)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+		return nil
+	},
+}
+
+// analysisResponse is the body of GET /issues/{id}/analysis
+type analysisResponse struct {
+	ID         string          `json:"id"`
+	Markdown   string          `json:"markdown,omitempty"`
+	Structured *model.Analysis `json:"structured,omitempty"`
+	Modified   time.Time       `json:"modified"`
+}
+
+// issuesHandler routes the read-only issue endpoints
+func issuesHandler(s *storage.Storage) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /issues", func(w http.ResponseWriter, r *http.Request) {
+		idx, err := s.LoadIndex()
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		issues := idx.Issues
+		if issues == nil {
+			issues = []*model.Issue{}
+		}
+		writeJSON(w, issues)
+	})
+
+	mux.HandleFunc("GET /issues/{id}", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		issue, err := s.ExportIssue(id)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if issue == nil {
+			writeJSONError(w, http.StatusNotFound, fmt.Errorf("issue not found: %s", id))
+			return
+		}
+		writeJSON(w, issue)
+	})
+
+	mux.HandleFunc("GET /issues/{id}/analysis", func(w http.ResponseWriter, r *http.Request) {
+		id := r.PathValue("id")
+		idx, err := s.LoadIndex()
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if idx.GetIssue(id) == nil {
+			writeJSONError(w, http.StatusNotFound, fmt.Errorf("issue not found: %s", id))
+			return
+		}
+		artifacts, err := s.GetIssueWithArtifacts(id)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, err)
+			return
+		}
+		if !artifacts.HasAnalysis() {
+			writeJSONError(w, http.StatusNotFound, fmt.Errorf("issue %s has no analysis", id))
+			return
+		}
+		writeJSON(w, analysisResponse{
+			ID:         id,
+			Markdown:   artifacts.Analysis,
+			Structured: artifacts.AnalysisJSON,
+			Modified:   artifacts.AnalysisModified,
+		})
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(v)
+}
+
+func writeJSONError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
+
+func init() {
+	serveCmd.Flags().String("host", "localhost", "Interface to listen on")
+	serveCmd.Flags().Int("port", 8080, "Port to listen on")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var setStatusCmd = &cobra.Command{
+	Use:   "set-status <id> <status>",
+	Short: "Set an issue's status directly",
+	Long: `Set an issue's status directly, in both brief.md and index.yaml.
+
+A low-level escape hatch for scripts and for issues stuck in the wrong state: no
+analysis or plan is required, and nothing is committed. The status must be one of
+open, analyzed, planned, implemented, closed or invalid; --reason records why an
+issue was closed or discarded. Status hooks run and the changed files are staged,
+as when the TUI changes a status.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		reason, _ := cmd.Flags().GetString("reason")
+
+		statuses, err := parseStatuses(args[1:])
+		if err != nil {
+			return err
+		}
+		status := statuses[0]
+		if reason != "" && !status.IsClosed() {
+			return fmt.Errorf("--reason only applies to closed and invalid")
+		}
+
+		s, notifier, err := openStorage(path, issuesDir(cmd))
+		if err != nil {
+			return err
+		}
+		defer notifier.Wait()
+
+		issue, err := s.LoadBrief(args[0])
+		if err != nil {
+			return err
+		}
+		if issue == nil {
+			return fmt.Errorf("issue %s not found", args[0])
+		}
+		if issue.Status == status {
+			fmt.Printf("%s is already %s\n", issue.ID, status)
+			return nil
+		}
+
+		if err := s.UpdateIssueStatus(issue.ID, status, reason); err != nil {
+			return err
+		}
+		fmt.Printf("%s: %s → %s\n", issue.ID, issue.Status, status)
+		return nil
+	},
+}
+
+func init() {
+	setStatusCmd.Flags().String("reason", "", "Why the issue was closed or discarded")
+	rootCmd.AddCommand(setStatusCmd)
+}
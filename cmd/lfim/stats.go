@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Sum Claude token usage and cost across issues",
+	Long: `Print the Claude calls, tokens and cost recorded for each issue, and their total.
+
+Usage is recorded in each issue's .usage.json as the TUI and "lfim watch" run
+Claude; calls by CLI versions that don't report usage count as zero. Input
+tokens include cache reads and writes. --json prints the same for scripting.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		asJSON, _ := cmd.Flags().GetBool("json")
+
+		s := storage.New(path, issuesDir(cmd))
+		if _, err := os.Stat(s.IssuesDir); err != nil {
+			return fmt.Errorf("no issues directory at %s", s.IssuesDir)
+		}
+		idx, err := s.LoadIndex()
+		if err != nil {
+			return err
+		}
+
+		type issueUsage struct {
+			ID    string      `json:"id"`
+			Title string      `json:"title"`
+			Usage model.Usage `json:"usage"`
+		}
+		report := struct {
+			Issues []issueUsage `json:"issues"`
+			Total  model.Usage  `json:"total"`
+		}{Issues: []issueUsage{}}
+		for _, issue := range idx.Issues {
+			usage, err := s.LoadUsage(issue.ID)
+			if err != nil {
+				return err
+			}
+			if usage.IsZero() {
+				continue
+			}
+			report.Issues = append(report.Issues, issueUsage{ID: issue.ID, Title: issue.Title, Usage: usage})
+			report.Total.Add(usage)
+		}
+
+		if asJSON {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			return enc.Encode(report)
+		}
+		if len(report.Issues) == 0 {
+			fmt.Println("No Claude usage recorded")
+			return nil
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		row := func(id string, u model.Usage, title string) {
+			fmt.Fprintf(w, "%s\t%d\t%s\t%s\t$%.2f\t%s\n", id, u.Calls, model.FormatTokens(u.TotalInputTokens()), model.FormatTokens(u.OutputTokens), u.CostUSD, title)
+		}
+		fmt.Fprintln(w, "ID\tCALLS\tINPUT\tOUTPUT\tCOST\tTITLE")
+		for _, issue := range report.Issues {
+			row(issue.ID, issue.Usage, issue.Title)
+		}
+		row("TOTAL", report.Total, "")
+		return w.Flush()
+	},
+}
+
+func init() {
+	statsCmd.Flags().Bool("json", false, "Print usage as JSON")
+	rootCmd.AddCommand(statsCmd)
+}
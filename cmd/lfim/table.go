@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-isatty"
+	"github.com/mattn/go-runewidth"
+)
+
+// colorOutput reports whether to color what's printed to stdout: only on a
+// terminal, and not when NO_COLOR is set
+func colorOutput() bool {
+	return os.Getenv("NO_COLOR") == "" && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+// writeTable prints a header and rows as columns two spaces apart. Cells are padded
+// by display width, so wide (e.g. CJK) text lines up; the last column isn't padded.
+// style, if non-nil, returns the style of a row's cell (row -1 is the header) and is
+// applied after padding.
+func writeTable(w io.Writer, header []string, rows [][]string, style func(row, col int) lipgloss.Style) error {
+	widths := make([]int, len(header))
+	for _, row := range append([][]string{header}, rows...) {
+		for col, cell := range row {
+			widths[col] = max(widths[col], runewidth.StringWidth(cell))
+		}
+	}
+
+	for i, row := range append([][]string{header}, rows...) {
+		var line strings.Builder
+		for col, cell := range row {
+			if col > 0 {
+				line.WriteString("  ")
+			}
+			if col < len(row)-1 {
+				cell += strings.Repeat(" ", widths[col]-runewidth.StringWidth(cell))
+			}
+			if style != nil {
+				cell = style(i-1, col).Render(cell)
+			}
+			line.WriteString(cell)
+		}
+		if _, err := fmt.Fprintln(w, strings.TrimRight(line.String(), " ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// formatAge renders how long ago t was, e.g. "today", "3d", "2w", "5mo", "1y"
+func formatAge(t time.Time, now time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	days := int(now.Sub(t).Hours() / 24)
+	switch {
+	case days < 1:
+		return "today"
+	case days < 14:
+		return fmt.Sprintf("%dd", days)
+	case days < 60:
+		return fmt.Sprintf("%dw", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo", days/30)
+	}
+	return fmt.Sprintf("%dy", days/365)
+}
@@ -0,0 +1,306 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/cobra"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Analyze new issues automatically as their briefs appear",
+	Long: `Watch the issues directory and analyze new issues automatically.
+
+Whenever an issue's brief.md is created or written, lfim waits for the file to
+settle (--debounce) and then runs the same analysis as the TUI's [a] key if the
+issue is open, its brief has a body, and it has no analysis yet. At most
+--concurrency analyses run at once. Actions are logged to stdout.
+
+Issues that already exist when watch starts are not analyzed until their brief is
+next written, so filling in an empty brief or editing one that still has no
+analysis triggers it too. Stop with Ctrl+C: running analyses are cancelled and
+nothing is saved for them.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path, _ := cmd.Flags().GetString("path")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		debounce, _ := cmd.Flags().GetDuration("debounce")
+
+		if concurrency < 1 {
+			return fmt.Errorf("--concurrency must be at least 1")
+		}
+
+		cfg, err := loadConfig(cmd, path)
+		if err != nil {
+			return err
+		}
+		s, notifier, err := openStorage(path, issuesDir(cmd))
+		if err != nil {
+			return err
+		}
+		defer notifier.Wait()
+		if err := s.EnsureIssuesDir(); err != nil {
+			return err
+		}
+
+		c := claude.New(s.ProjectRoot)
+		c.Executable = cfg.Claude.Command
+		c.ExtraArgs = cfg.Claude.ExtraArgs
+		c.ReadOnly = cfg.Claude.ReadOnly
+		c.Timeout = cfg.Claude.Timeout
+		c.SetMaxConcurrent(0) // w.slots limits analyses to --concurrency
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		w := &issueWatcher{
+			storage:  s,
+			claude:   c,
+			debounce: debounce,
+			guidance: cfg.Claude.LabelGuidance,
+			model:    cfg.Claude.ModelFor("analyze"),
+			slots:    make(chan struct{}, concurrency),
+			timers:   make(map[string]*time.Timer),
+			started:  make(map[string]bool),
+			logger:   log.New(os.Stdout, "", log.LstdFlags),
+		}
+		return w.run(ctx)
+	},
+}
+
+// issueWatcher turns brief.md events into debounced, concurrency-limited analyses
+type issueWatcher struct {
+	storage  *storage.Storage
+	claude   *claude.Client
+	debounce time.Duration
+	guidance map[string]string // prompt guidance by label, from claude.label_guidance
+	model    string            // analysis model; "" for the CLI default
+	text     bool              // ask for a markdown analysis instead of structured JSON
+	slots    chan struct{}     // one token per running analysis
+	logger   *log.Logger
+
+	mu      sync.Mutex
+	timers  map[string]*time.Timer // pending debounced checks by issue ID
+	started map[string]bool        // issues queued or analyzed this session
+	running sync.WaitGroup
+}
+
+// run watches until ctx is cancelled, then waits for running analyses to stop
+func (w *issueWatcher) run(ctx context.Context) error {
+	fw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("starting watcher: %w", err)
+	}
+	defer fw.Close()
+
+	if err := fw.Add(w.storage.IssuesDir); err != nil {
+		return fmt.Errorf("watching %s: %w", w.storage.IssuesDir, err)
+	}
+	// Existing issue directories, so edits that fill in an empty brief are seen
+	entries, _ := os.ReadDir(w.storage.IssuesDir)
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			_ = fw.Add(filepath.Join(w.storage.IssuesDir, entry.Name()))
+		}
+	}
+
+	w.logger.Printf("Watching %s for new issues (concurrency %d, debounce %s)", w.storage.IssuesDir, cap(w.slots), w.debounce)
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.mu.Lock()
+			for _, timer := range w.timers {
+				timer.Stop()
+			}
+			w.mu.Unlock()
+			w.logger.Printf("Stopping")
+			w.running.Wait()
+			return nil
+
+		case event, ok := <-fw.Events:
+			if !ok {
+				return nil
+			}
+			w.handleEvent(ctx, fw, event)
+
+		case err, ok := <-fw.Errors:
+			if !ok {
+				return nil
+			}
+			w.logger.Printf("Watch error: %v", err)
+		}
+	}
+}
+
+// handleEvent schedules a check for the issue a filesystem event belongs to
+func (w *issueWatcher) handleEvent(ctx context.Context, fw *fsnotify.Watcher, event fsnotify.Event) {
+	if !event.Has(fsnotify.Create) && !event.Has(fsnotify.Write) {
+		return
+	}
+	rel, err := filepath.Rel(w.storage.IssuesDir, event.Name)
+	if err != nil {
+		return
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	issueID := parts[0]
+	if strings.HasPrefix(issueID, ".") {
+		return
+	}
+
+	switch {
+	case len(parts) == 1 && event.Has(fsnotify.Create):
+		info, err := os.Stat(event.Name)
+		if err != nil || !info.IsDir() {
+			return
+		}
+		if err := fw.Add(event.Name); err != nil {
+			w.logger.Printf("%s: cannot watch: %v", issueID, err)
+			return
+		}
+		// brief.md may have been written before the directory was watched
+		w.schedule(ctx, issueID)
+	case len(parts) == 2 && parts[1] == "brief.md":
+		w.schedule(ctx, issueID)
+	}
+}
+
+// schedule (re)starts the debounce timer for an issue, so a burst of writes leads
+// to a single check once the brief has settled
+func (w *issueWatcher) schedule(ctx context.Context, issueID string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if timer, ok := w.timers[issueID]; ok {
+		timer.Reset(w.debounce)
+		return
+	}
+	w.timers[issueID] = time.AfterFunc(w.debounce, func() {
+		w.check(ctx, issueID)
+	})
+}
+
+// check starts an analysis if the issue is open, has a non-empty brief and no
+// analysis yet
+func (w *issueWatcher) check(ctx context.Context, issueID string) {
+	w.mu.Lock()
+	delete(w.timers, issueID)
+	if w.started[issueID] || ctx.Err() != nil {
+		w.mu.Unlock()
+		return
+	}
+
+	issue, err := w.storage.LoadBrief(issueID)
+	switch {
+	case err != nil:
+		w.logger.Printf("%s: cannot load brief: %v", issueID, err)
+	case issue == nil || issue.Status != model.StatusOpen:
+	case strings.TrimSpace(issue.Content) == "":
+		w.logger.Printf("%s: brief is empty, waiting for a body", issueID)
+	case w.storage.AnalysisJSONExists(issueID) || w.storage.AnalysisExists(issueID):
+	default:
+		w.started[issueID] = true
+		w.running.Add(1)
+		go func() {
+			defer w.running.Done()
+			if w.acquire(ctx) {
+				defer w.release()
+				w.analyze(ctx, issue)
+			}
+		}()
+	}
+	w.mu.Unlock()
+}
+
+// acquire waits for a free concurrency slot, returning false if ctx is done first
+func (w *issueWatcher) acquire(ctx context.Context) bool {
+	select {
+	case w.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// release frees the slot taken by acquire
+func (w *issueWatcher) release() {
+	<-w.slots
+}
+
+// analyze runs one analysis in a slot the caller holds and reports whether it was
+// saved
+func (w *issueWatcher) analyze(ctx context.Context, issue *model.Issue) bool {
+	w.logger.Printf("%s: analyzing %q", issue.ID, issue.Title)
+	results := make(chan claude.TaskResult, 1)
+	w.claude.RunAsync(ctx, issue.ID, "analyze", w.prompt(issue), w.model, "", results)
+	result := <-results
+	if !result.Usage.IsZero() {
+		_ = w.storage.AddUsage(issue.ID, result.Usage)
+	}
+	if result.Stderr != "" {
+		_ = w.storage.AppendClaudeLog(issue.ID, result.TaskType, result.Stderr)
+	}
+
+	switch {
+	case result.Cancelled:
+		w.logger.Printf("%s: analysis cancelled", issue.ID)
+		return false
+	case !result.Success:
+		w.logger.Printf("%s: analysis failed: %s", issue.ID, firstLine(result.Result))
+		// Let a later edit of the brief retry
+		w.mu.Lock()
+		delete(w.started, issue.ID)
+		w.mu.Unlock()
+		return false
+	}
+
+	structured, err := w.storage.SaveAnalysisResult(issue.ID, result.Result, result.SessionID)
+	switch {
+	case err != nil:
+		w.logger.Printf("%s: saving analysis failed: %v", issue.ID, err)
+		return false
+	case structured:
+		w.logger.Printf("%s: analyzed", issue.ID)
+	default:
+		w.logger.Printf("%s: analyzed (text mode)", issue.ID)
+	}
+	return true
+}
+
+// prompt builds the analysis prompt for issue: structured, as the TUI's [a] key
+// does, unless w.text is set
+func (w *issueWatcher) prompt(issue *model.Issue) string {
+	build := claude.BuildAnalysisPromptJSON
+	if w.text {
+		build = claude.BuildAnalysisPrompt
+	}
+	prompt := build(issue.Content, w.storage.BriefPath(issue.ID), issue.Type)
+	return claude.WithLabelGuidance(prompt, issue.Labels, w.guidance)
+}
+
+func firstLine(s string) string {
+	s = strings.TrimSpace(s)
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+func init() {
+	watchCmd.Flags().Int("concurrency", 2, "Maximum analyses running at once")
+	watchCmd.Flags().Duration("debounce", 2*time.Second, "Wait this long after the last write to a brief before checking it")
+	rootCmd.AddCommand(watchCmd)
+}
@@ -1,15 +1,47 @@
 package claude
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os/exec"
+	"slices"
 	"strings"
+	"sync"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
 )
 
-// Response represents the Claude CLI JSON response
+// Response represents the Claude CLI JSON response. Usage and cost are zero for
+// CLI versions that don't report them.
 type Response struct {
-	Result    string `json:"result"`
-	SessionID string `json:"session_id,omitempty"`
+	Result       string        `json:"result"`
+	SessionID    string        `json:"session_id,omitempty"`
+	Usage        responseUsage `json:"usage"`
+	TotalCostUSD float64       `json:"total_cost_usd"`
+}
+
+// responseUsage is the token counts of a Claude CLI response
+type responseUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// usage converts the response's token counts and cost into one call's model.Usage
+func (r Response) usage() model.Usage {
+	return model.Usage{
+		Calls:                    1,
+		InputTokens:              r.Usage.InputTokens,
+		OutputTokens:             r.Usage.OutputTokens,
+		CacheCreationInputTokens: r.Usage.CacheCreationInputTokens,
+		CacheReadInputTokens:     r.Usage.CacheReadInputTokens,
+		CostUSD:                  r.TotalCostUSD,
+	}
 }
 
 // TaskResult represents the result of an async Claude task
@@ -19,21 +51,184 @@ type TaskResult struct {
 	Success   bool
 	Result    string
 	SessionID string
+	Stderr    string      // the CLI's stderr, e.g. warnings alongside a successful result
+	Usage     model.Usage // tokens and cost as reported by the CLI
+	TimedOut  bool        // the CLI was killed after Client.Timeout; Result explains
+	Cancelled bool        // the caller cancelled the call's context
+}
+
+// DefaultMaxConcurrent is how many async Claude calls run at once unless configured otherwise
+const DefaultMaxConcurrent = 2
+
+// DefaultTimeout bounds a single Claude CLI call unless configured otherwise
+const DefaultTimeout = 2 * time.Minute
+
+// waitDelay bounds how long a cancelled call waits for its output to close
+const waitDelay = 5 * time.Second
+
+// writableTasks are the task types allowed to edit files when ReadOnly is off
+var writableTasks = map[string]bool{
+	"review":      true,
+	"plan-review": true,
 }
 
+// DefaultExecutable is the CLI a Client runs unless told otherwise
+const DefaultExecutable = "claude"
+
 // Client handles Claude CLI interactions
 type Client struct {
 	WorkingDir string
+	Metrics    *Metrics
+
+	// Executable is the CLI to run. Any replacement for claude must accept the same
+	// flags (-p, --output-format json, --model, --resume, --permission-mode) and
+	// print a JSON object with "result" and "session_id"; other output is taken as
+	// the plain-text result.
+	Executable string
+	// ExtraArgs precede the arguments of every call, e.g. a wrapper's own options
+	ExtraArgs []string
+
+	// ReadOnly keeps Claude from editing files during review tasks (default true).
+	// When false, review and plan-review runs may edit files without prompting.
+	ReadOnly bool
+
+	// Timeout kills a Claude CLI call that runs longer than this; zero disables it.
+	// RunImplement ignores it: an implementation may legitimately run for long.
+	Timeout time.Duration
+
+	slots   chan struct{} // one token per running async call; nil means unlimited
+	queueMu sync.Mutex
+	queued  map[string]int // issue ID -> async calls waiting for a slot
 }
 
 // New creates a new Claude client
 func New(workingDir string) *Client {
-	return &Client{WorkingDir: workingDir}
+	return &Client{
+		WorkingDir: workingDir,
+		Metrics:    NewMetrics(),
+		Executable: DefaultExecutable,
+		ReadOnly:   true,
+		Timeout:    DefaultTimeout,
+		slots:      make(chan struct{}, DefaultMaxConcurrent),
+		queued:     make(map[string]int),
+	}
+}
+
+// SetMaxConcurrent limits how many RunAsync/RunAsyncStreaming calls run at once;
+// further calls queue until one finishes. n <= 0 removes the limit. Call it before
+// starting any calls.
+func (c *Client) SetMaxConcurrent(n int) {
+	if n <= 0 {
+		c.slots = nil
+		return
+	}
+	c.slots = make(chan struct{}, n)
+}
+
+// Queued reports whether an async call for the issue is waiting for a free slot
+func (c *Client) Queued(issueID string) bool {
+	c.queueMu.Lock()
+	defer c.queueMu.Unlock()
+	return c.queued[issueID] > 0
+}
+
+// acquire waits for a free slot, queued under issueID meanwhile. It returns false
+// if ctx is done first.
+func (c *Client) acquire(ctx context.Context, issueID string) bool {
+	if c.slots == nil {
+		return true
+	}
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	default:
+	}
+
+	c.queueMu.Lock()
+	c.queued[issueID]++
+	c.queueMu.Unlock()
+	defer func() {
+		c.queueMu.Lock()
+		if c.queued[issueID]--; c.queued[issueID] <= 0 {
+			delete(c.queued, issueID)
+		}
+		c.queueMu.Unlock()
+	}()
+
+	select {
+	case c.slots <- struct{}{}:
+		return true
+	case <-ctx.Done():
+		return false
+	}
 }
 
-// Run executes Claude CLI and returns (success, result, sessionID)
+// release frees the slot taken by acquire
+func (c *Client) release() {
+	if c.slots != nil {
+		<-c.slots
+	}
+}
+
+// Run executes Claude CLI and returns (success, result, sessionID). The call is
+// killed after c.Timeout.
 func (c *Client) Run(prompt string, model string, resumeSession string) (bool, string, string) {
+	ctx, cancel := c.withTimeout(context.Background())
+	defer cancel()
+	result := c.runTimed(ctx, "run", prompt, model, resumeSession)
+	return result.Success, result.Result, result.SessionID
+}
+
+// withTimeout bounds ctx by c.Timeout, if set
+func (c *Client) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if c.Timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, c.Timeout)
+}
+
+// interrupted explains why ctx ended a call early, or returns "" if it didn't
+func (c *Client) interrupted(ctx context.Context) string {
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		return fmt.Sprintf("timed out after %s", c.Timeout)
+	case errors.Is(ctx.Err(), context.Canceled):
+		return "cancelled"
+	}
+	return ""
+}
+
+// runTimed executes Claude CLI and records the call duration under taskType
+func (c *Client) runTimed(ctx context.Context, taskType, prompt, model, resumeSession string) TaskResult {
+	var extraArgs []string
+	if !c.ReadOnly && writableTasks[taskType] {
+		extraArgs = append(extraArgs, "--permission-mode", "acceptEdits")
+	}
+
+	start := time.Now()
+	result := c.run(ctx, prompt, model, resumeSession, extraArgs...)
+	c.Metrics.Record(taskType, time.Since(start), result.Success)
+	return result
+}
+
+// RunImplement runs an implement prompt in print mode on a resumed session, letting
+// Claude edit files without prompting. This modifies code: callers must obtain
+// explicit consent before calling it. Implementation runs are not bounded by Timeout.
+// The result carries the call's usage for the caller to record.
+func (c *Client) RunImplement(prompt, resumeSession string) TaskResult {
+	start := time.Now()
+	result := c.run(context.Background(), prompt, "", resumeSession, "--permission-mode", "acceptEdits")
+	result.TaskType = "implement"
+	c.Metrics.Record(result.TaskType, time.Since(start), result.Success)
+	return result
+}
+
+// run executes Claude CLI without instrumentation. Only the call's outcome fields
+// of the returned TaskResult are set; a failed call's Result is its stderr when
+// there is any.
+func (c *Client) run(ctx context.Context, prompt, model, resumeSession string, extraArgs ...string) TaskResult {
 	args := []string{"--output-format", "json"}
+	args = append(args, extraArgs...)
 
 	if model != "" {
 		args = append(args, "--model", model)
@@ -43,46 +238,90 @@ func (c *Client) Run(prompt string, model string, resumeSession string) (bool, s
 	}
 	args = append(args, "-p", prompt)
 
-	cmd := exec.Command("claude", args...)
-	cmd.Dir = c.WorkingDir
+	cmd := c.CommandContext(ctx, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
 
-	output, err := cmd.Output()
+	err := cmd.Run()
+	errOutput := strings.TrimSpace(stderr.String())
 	if err != nil {
-		if exitErr, ok := err.(*exec.ExitError); ok {
-			return false, string(exitErr.Stderr), ""
-		}
-		return false, err.Error(), ""
+		return c.failure(ctx, err, errOutput)
+	}
+
+	result := c.parseResponse(stdout.String())
+	result.Stderr = errOutput
+	return result
+}
+
+// failure is the result of a call whose process failed with err
+func (c *Client) failure(ctx context.Context, err error, stderr string) TaskResult {
+	result := TaskResult{Result: err.Error(), Stderr: stderr}
+	if reason := c.interrupted(ctx); reason != "" {
+		result.Result = reason
+	} else if stderr != "" {
+		result.Result = stderr
 	}
+	return result
+}
 
-	return c.parseResponse(string(output))
+// Command builds a Claude CLI command (c.Executable with c.ExtraArgs) that runs in
+// the project root, so claude's project-level settings and context are picked up.
+// All invocations go through here.
+func (c *Client) Command(args ...string) *exec.Cmd {
+	return c.CommandContext(context.Background(), args...)
 }
 
-// RunAsync executes Claude CLI in a goroutine and sends result to channel
-func (c *Client) RunAsync(issueID, taskType, prompt, model, resumeSession string, resultChan chan<- TaskResult) {
+// CommandContext is Command with the process killed when ctx is done
+func (c *Client) CommandContext(ctx context.Context, args ...string) *exec.Cmd {
+	args = append(slices.Clone(c.ExtraArgs), args...)
+	cmd := exec.CommandContext(ctx, c.Executable, args...)
+	cmd.Dir = c.WorkingDir
+	// Children of a killed claude (e.g. tool subprocesses) can hold its output
+	// open; stop waiting for them shortly after the kill
+	cmd.WaitDelay = waitDelay
+	return cmd
+}
+
+// RunAsync executes Claude CLI in a goroutine and sends result to channel. The call
+// waits for a free slot (see SetMaxConcurrent), then is killed when ctx is cancelled
+// or after c.Timeout.
+func (c *Client) RunAsync(ctx context.Context, issueID, taskType, prompt, model, resumeSession string, resultChan chan<- TaskResult) {
 	go func() {
-		success, result, sessionID := c.Run(prompt, model, resumeSession)
-		resultChan <- TaskResult{
-			IssueID:   issueID,
-			TaskType:  taskType,
-			Success:   success,
-			Result:    result,
-			SessionID: sessionID,
+		if !c.acquire(ctx, issueID) {
+			resultChan <- cancelledResult(issueID, taskType)
+			return
 		}
+		defer c.release()
+
+		ctx, cancel := c.withTimeout(ctx)
+		defer cancel()
+
+		result := c.runTimed(ctx, taskType, prompt, model, resumeSession)
+		result.IssueID = issueID
+		result.TaskType = taskType
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		result.Cancelled = errors.Is(ctx.Err(), context.Canceled)
+		resultChan <- result
 	}()
 }
 
+// cancelledResult is the result of a call cancelled while it was queued
+func cancelledResult(issueID, taskType string) TaskResult {
+	return TaskResult{IssueID: issueID, TaskType: taskType, Result: "cancelled", Cancelled: true}
+}
+
 // parseResponse parses Claude CLI JSON output
-func (c *Client) parseResponse(jsonOutput string) (bool, string, string) {
+func (c *Client) parseResponse(jsonOutput string) TaskResult {
 	var resp Response
 	if err := json.Unmarshal([]byte(jsonOutput), &resp); err != nil {
 		// Fallback: treat as plain text
-		return true, strings.TrimSpace(jsonOutput), ""
+		return TaskResult{Success: true, Result: strings.TrimSpace(jsonOutput)}
 	}
-	return true, resp.Result, resp.SessionID
+	return TaskResult{Success: true, Result: resp.Result, SessionID: resp.SessionID, Usage: resp.usage()}
 }
 
-// IsAvailable checks if claude CLI is available
-func IsAvailable() bool {
-	cmd := exec.Command("claude", "--version")
-	return cmd.Run() == nil
+// IsAvailable checks if the configured CLI is available
+func (c *Client) IsAvailable() bool {
+	return c.Command("--version").Run() == nil
 }
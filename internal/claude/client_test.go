@@ -0,0 +1,179 @@
+package claude
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"testing"
+	"time"
+)
+
+// hangingClient returns a client whose CLI records its PID and then sleeps far
+// longer than the client's timeout
+func hangingClient(t *testing.T) (*Client, string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake CLI is a shell script")
+	}
+	dir := t.TempDir()
+	pidFile := filepath.Join(dir, "pid")
+	script := filepath.Join(dir, "claude")
+	body := "#!/bin/sh\necho $$ > " + pidFile + "\nexec sleep 30\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(dir)
+	c.Executable = script
+	c.Timeout = 200 * time.Millisecond
+	return c, pidFile
+}
+
+// assertGone fails unless the process recorded in pidFile has exited
+func assertGone(t *testing.T, pidFile string) {
+	t.Helper()
+	data, err := os.ReadFile(pidFile)
+	if err != nil {
+		t.Fatalf("fake CLI never started: %v", err)
+	}
+	pid, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	proc, err := os.FindProcess(pid)
+	if err != nil {
+		return
+	}
+	if err := proc.Signal(syscall.Signal(0)); err == nil {
+		_ = proc.Kill()
+		t.Fatalf("process %d still running after the timeout", pid)
+	}
+}
+
+func TestRunTimesOut(t *testing.T) {
+	c, pidFile := hangingClient(t)
+
+	start := time.Now()
+	success, result, _ := c.Run("prompt", "", "")
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Fatalf("Run returned after %s, want about %s", elapsed, c.Timeout)
+	}
+	if success {
+		t.Fatal("Run succeeded, want a timeout failure")
+	}
+	if want := "timed out after 200ms"; result != want {
+		t.Errorf("result = %q, want %q", result, want)
+	}
+	assertGone(t, pidFile)
+}
+
+func TestRunAsyncTimesOut(t *testing.T) {
+	c, pidFile := hangingClient(t)
+
+	results := make(chan TaskResult, 1)
+	c.RunAsync(context.Background(), "0001", "analyze", "prompt", "", "", results)
+
+	select {
+	case result := <-results:
+		if result.Success || !result.TimedOut || result.Cancelled {
+			t.Fatalf("result = %+v, want a timed-out failure", result)
+		}
+		if !strings.Contains(result.Result, "timed out") {
+			t.Errorf("result message = %q, want it to mention the timeout", result.Result)
+		}
+		if result.IssueID != "0001" || result.TaskType != "analyze" {
+			t.Errorf("result is for %s/%s, want 0001/analyze", result.IssueID, result.TaskType)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunAsync did not return after the timeout")
+	}
+	assertGone(t, pidFile)
+}
+
+func TestRunAsyncCancelled(t *testing.T) {
+	c, pidFile := hangingClient(t)
+	c.Timeout = 0
+
+	ctx, cancel := context.WithCancel(context.Background())
+	results := make(chan TaskResult, 1)
+	c.RunAsync(ctx, "0001", "analyze", "prompt", "", "", results)
+
+	// Cancel once the CLI is running
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		if _, err := os.Stat(pidFile); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("fake CLI never started")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+
+	select {
+	case result := <-results:
+		if result.Success || !result.Cancelled || result.TimedOut {
+			t.Fatalf("result = %+v, want a cancelled failure", result)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunAsync did not return after cancellation")
+	}
+	assertGone(t, pidFile)
+}
+
+// TestCallsRunInProjectRoot checks that every way of invoking the CLI runs it in
+// the client's working directory, whatever the caller's current directory
+func TestCallsRunInProjectRoot(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake CLI is a shell script")
+	}
+	script := filepath.Join(t.TempDir(), "claude")
+	body := "#!/bin/sh\nprintf '{\"type\":\"result\",\"result\":\"%s\",\"session_id\":\"s\"}\\n' \"$(pwd -P)\"\n"
+	if err := os.WriteFile(script, []byte(body), 0755); err != nil {
+		t.Fatal(err)
+	}
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	c := New(root)
+	c.Executable = script
+
+	runAsync := func(streaming bool) string {
+		results := make(chan TaskResult, 1)
+		if streaming {
+			c.RunAsyncStreaming(context.Background(), "0001", "analyze", "prompt", "", "", filepath.Join(t.TempDir(), "partial"), results)
+		} else {
+			c.RunAsync(context.Background(), "0001", "analyze", "prompt", "", "", results)
+		}
+		return (<-results).Result
+	}
+
+	for name, call := range map[string]func() string{
+		"Run": func() string {
+			_, result, _ := c.Run("prompt", "", "")
+			return result
+		},
+		"RunAsync":          func() string { return runAsync(false) },
+		"RunAsyncStreaming": func() string { return runAsync(true) },
+		"RunImplement": func() string {
+			return c.RunImplement("prompt", "s").Result
+		},
+		"Command": func() string {
+			output, err := c.Command("--version").Output()
+			if err != nil {
+				t.Fatal(err)
+			}
+			return c.parseResponse(string(output)).Result
+		},
+	} {
+		if got := call(); got != root {
+			t.Errorf("%s ran in %q, want the project root %q", name, got, root)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package claude
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// Estimate projects what a batch of Claude calls will use before running them
+type Estimate struct {
+	Calls        int
+	PromptTokens int     // tokens in the prompts themselves; Claude reads files on top
+	CostUSD      float64 // Calls times the average recorded cost per call; 0 without history
+	HistoryCalls int     // recorded calls the cost is averaged over
+}
+
+// EstimateTokens approximates the tokens in text at about four characters each
+func EstimateTokens(text string) int {
+	return (utf8.RuneCountInString(text) + 3) / 4
+}
+
+// EstimateCalls projects the usage of one call per prompt. The cost is the average
+// cost per call in history (e.g. every issue's recorded usage), so it reflects how
+// much a typical call in this project reads and writes.
+func EstimateCalls(prompts []string, history model.Usage) Estimate {
+	e := Estimate{Calls: len(prompts), HistoryCalls: history.Calls}
+	for _, prompt := range prompts {
+		e.PromptTokens += EstimateTokens(prompt)
+	}
+	if history.Calls > 0 {
+		e.CostUSD = float64(e.Calls) * history.CostUSD / float64(history.Calls)
+	}
+	return e
+}
+
+// String summarizes the estimate, e.g. "~4.2k prompt tokens, ~$0.36 (average of 12
+// recorded calls)"
+func (e Estimate) String() string {
+	s := fmt.Sprintf("~%s prompt tokens", model.FormatTokens(e.PromptTokens))
+	if e.HistoryCalls == 0 || e.CostUSD == 0 {
+		return s + ", cost unknown until calls with usage are recorded"
+	}
+	return s + fmt.Sprintf(", ~$%.2f (average of %d recorded calls)", e.CostUSD, e.HistoryCalls)
+}
@@ -0,0 +1,100 @@
+package claude
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// TaskTiming holds aggregate timing data for a single task type
+type TaskTiming struct {
+	Calls    int
+	Failures int
+	Total    time.Duration
+}
+
+// Average returns the mean duration per call
+func (t TaskTiming) Average() time.Duration {
+	if t.Calls == 0 {
+		return 0
+	}
+	return t.Total / time.Duration(t.Calls)
+}
+
+// FailureRate returns the fraction of failed calls (0.0 - 1.0)
+func (t TaskTiming) FailureRate() float64 {
+	if t.Calls == 0 {
+		return 0
+	}
+	return float64(t.Failures) / float64(t.Calls)
+}
+
+// Metrics accumulates in-memory timings for Claude CLI calls during a session
+type Metrics struct {
+	mu    sync.Mutex
+	tasks map[string]TaskTiming
+}
+
+// NewMetrics creates an empty metrics collector
+func NewMetrics() *Metrics {
+	return &Metrics{tasks: make(map[string]TaskTiming)}
+}
+
+// Record adds a single call's duration and outcome to the task's totals
+func (m *Metrics) Record(taskType string, d time.Duration, success bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	t := m.tasks[taskType]
+	t.Calls++
+	t.Total += d
+	if !success {
+		t.Failures++
+	}
+	m.tasks[taskType] = t
+}
+
+// Snapshot returns a copy of the current per-task timings
+func (m *Metrics) Snapshot() map[string]TaskTiming {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	snapshot := make(map[string]TaskTiming, len(m.tasks))
+	for k, v := range m.tasks {
+		snapshot[k] = v
+	}
+	return snapshot
+}
+
+// Summary renders the timings as an aligned table, or "" if nothing was recorded
+func (m *Metrics) Summary() string {
+	snapshot := m.Snapshot()
+	if len(snapshot) == 0 {
+		return ""
+	}
+
+	taskTypes := make([]string, 0, len(snapshot))
+	for k := range snapshot {
+		taskTypes = append(taskTypes, k)
+	}
+	sort.Strings(taskTypes)
+
+	var sb strings.Builder
+	w := tabwriter.NewWriter(&sb, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TASK\tCALLS\tFAILED\tAVG\tTOTAL")
+	for _, taskType := range taskTypes {
+		t := snapshot[taskType]
+		fmt.Fprintf(w, "%s\t%d\t%.0f%%\t%s\t%s\n",
+			taskType,
+			t.Calls,
+			t.FailureRate()*100,
+			t.Average().Round(100*time.Millisecond),
+			t.Total.Round(100*time.Millisecond),
+		)
+	}
+	w.Flush()
+	return sb.String()
+}
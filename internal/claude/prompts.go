@@ -3,6 +3,7 @@ package claude
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/lunit-heesungyang/issue-manager/internal/model"
 )
@@ -23,22 +24,109 @@ REQUIRED BEHAVIOR:
 
 `
 
-// BuildAnalysisPrompt builds the analysis prompt
-func BuildAnalysisPrompt(briefContent, briefPath string) string {
+// trustedConstraints replaces readOnlyConstraints for review prompts when the user has
+// disabled read-only mode. Claude may edit files, but the host still saves the response.
+const trustedConstraints = `## System Constraints
+You may read and modify files in this repository if it helps the revision.
+
+REQUIRED BEHAVIOR:
+- Return the complete revised document as your direct text response
+- Start responses immediately with content (no preamble or meta-commentary)
+- The host application saves your response over the document, so any direct edit to it is replaced
+
+`
+
+// reviewConstraints returns the constraints block for review prompts
+func reviewConstraints(readOnly bool) string {
+	if readOnly {
+		return readOnlyConstraints
+	}
+	return trustedConstraints
+}
+
+// analysisFocus is the type-specific framing of an analysis: the heading of its first
+// section and what the analysis should concentrate on
+type analysisFocus struct {
+	Heading  string
+	Guidance string
+}
+
+// analysisFocusFor returns the framing for an issue type, with a generic fallback
+// for unknown types
+func analysisFocusFor(issueType model.IssueType) analysisFocus {
+	switch issueType {
+	case model.TypeBug:
+		return analysisFocus{
+			Heading: "Root cause",
+			Guidance: `This is a BUG report. Concentrate on finding the root cause:
+- Trace the failing behavior to the code responsible and explain why it happens
+- Note how to reproduce it and what else the same defect may affect
+- Prefer fixes that address the cause over ones that mask the symptom`,
+		}
+	case model.TypeFeature:
+		return analysisFocus{
+			Heading: "Feature scope",
+			Guidance: `This is a FEATURE request. Concentrate on scope and design:
+- Define what is in and out of scope, and any open questions about the requirements
+- Identify where the feature fits in the existing architecture and what it touches
+- Compare designs by user impact, complexity and how well they fit existing patterns`,
+		}
+	case model.TypeRefactor:
+		return analysisFocus{
+			Heading: "Maintainability concerns",
+			Guidance: `This is a REFACTOR. Concentrate on maintainability:
+- Describe the structural problems in the current code and their cost
+- Behavior must not change: identify what could regress and how to verify it
+- Prefer incremental steps that keep the code working between them`,
+		}
+	default:
+		return analysisFocus{
+			Heading:  "Root cause / Feature scope",
+			Guidance: "Determine whether this is a defect or a new capability and analyze it accordingly.",
+		}
+	}
+}
+
+// BuildAnalysisPrompt builds the analysis prompt, framed for the issue type
+func BuildAnalysisPrompt(briefContent, briefPath string, issueType model.IssueType) string {
+	focus := analysisFocusFor(issueType)
 	return fmt.Sprintf(`%s## Task
 Analyze this issue and provide:
-1. Root cause / Feature scope
+1. %s
 2. Implementation options with pros/cons
 3. Recommended approach
 4. Risk assessment
 
+## Focus
+%s
+
 Issue (%s):
 %s
 
 ## Output Format
 Return markdown content directly as your response text.
 Do NOT wrap output in code blocks.
-Start immediately with the first section header.`, readOnlyConstraints, briefPath, briefContent)
+Start immediately with the first section header.`, readOnlyConstraints, focus.Heading, focus.Guidance, briefPath, briefContent)
+}
+
+// WithLabelGuidance appends the guidance configured for the issue's labels to an
+// analysis or plan prompt. Labels without guidance are ignored; with none the
+// prompt is returned unchanged.
+func WithLabelGuidance(prompt string, labels []string, guidance map[string]string) string {
+	var lines []string
+	for _, label := range labels {
+		if text := strings.TrimSpace(guidance[label]); text != "" {
+			lines = append(lines, fmt.Sprintf("- %s: %s", label, text))
+		}
+	}
+	if len(lines) == 0 {
+		return prompt
+	}
+	return fmt.Sprintf(`%s
+
+## Project Guidance
+This issue is labeled for extra attention. Apply the following throughout:
+%s`, prompt, strings.Join(lines, "\n"))
 }
 
 // BuildPlanPrompt builds the plan prompt
@@ -85,8 +173,9 @@ Numbered list of specific tasks:
 - Plan created based on analysis`, readOnlyConstraints, briefContent, analysisContent)
 }
 
-// BuildReviewPrompt builds the review/refinement prompt
-func BuildReviewPrompt(analysisPath, feedback string) string {
+// BuildReviewPrompt builds the review/refinement prompt.
+// readOnly=false relaxes the file-write constraints (see config claude.read_only).
+func BuildReviewPrompt(analysisPath, feedback string, readOnly bool) string {
 	return fmt.Sprintf(`%s## Context
 The current analysis is in: %s
 
@@ -107,11 +196,12 @@ IMPORTANT: The revised analysis MUST contain all the same section headers as the
 ## Output Format
 Return the complete revised analysis as markdown.
 Start immediately with the first section header.
-Do NOT wrap output in code blocks.`, readOnlyConstraints, analysisPath, feedback)
+Do NOT wrap output in code blocks.`, reviewConstraints(readOnly), analysisPath, feedback)
 }
 
-// BuildPlanReviewPrompt builds the plan review/refinement prompt
-func BuildPlanReviewPrompt(planPath, feedback string) string {
+// BuildPlanReviewPrompt builds the plan review/refinement prompt.
+// readOnly=false relaxes the file-write constraints (see config claude.read_only).
+func BuildPlanReviewPrompt(planPath, feedback string, readOnly bool) string {
 	return fmt.Sprintf(`%s## Context
 The current implementation plan is in: %s
 
@@ -132,7 +222,7 @@ IMPORTANT: The revised plan MUST contain all the same section headers as the ori
 ## Output Format
 Return the complete revised plan as markdown.
 Start immediately with the first section header.
-Do NOT wrap output in code blocks.`, readOnlyConstraints, planPath, feedback)
+Do NOT wrap output in code blocks.`, reviewConstraints(readOnly), planPath, feedback)
 }
 
 // BuildImplementPrompt builds the implementation prompt for interactive mode
@@ -144,22 +234,68 @@ Then implement all tasks in the plan in order.
 After each significant change, verify it works correctly.`, planPath)
 }
 
+// BuildFreshImplementPrompt builds the implementation prompt for a new session, when
+// the analysis session can't be resumed: the brief and plan are included as context
+func BuildFreshImplementPrompt(briefContent, planContent, planPath string) string {
+	return fmt.Sprintf(`## Context
+This is a fresh session: the conversation in which the issue was analyzed and
+planned isn't available. Everything you need is below.
+
+### Brief
+%s
+
+### Implementation Plan (%s)
+%s
+
+## Task
+Implement all tasks in the plan in order.
+After each significant change, verify it works correctly.`, briefContent, planPath, planContent)
+}
+
+// CommitStyle describes the convention a generated commit message follows
+type CommitStyle struct {
+	Convention string // "conventional" (default), "gitmoji" or "plain"
+	Scope      string // fixed scope, e.g. the issue type or first label; empty lets the model choose one
+	NoScope    bool   // omit the scope entirely
+}
+
+// commitSubjectRules returns the subject-line requirements for a commit style
+func commitSubjectRules(style CommitStyle) string {
+	switch style.Convention {
+	case "gitmoji":
+		return `- First line: <emoji> brief description (max 72 chars)
+- Emoji (unicode, not :shortcode:): ✨ feature, 🐛 fix, ♻️ refactor, 📝 docs, 🔧 chore`
+	case "plain":
+		return `- First line: brief imperative description, capitalized, no prefix (max 72 chars)`
+	}
+
+	switch {
+	case style.NoScope:
+		return `- First line: type: brief description (max 72 chars)
+- Types: feat, fix, refactor, docs, chore`
+	case style.Scope != "":
+		return fmt.Sprintf(`- First line: type(%s): brief description (max 72 chars)
+- Types: feat, fix, refactor, docs, chore`, style.Scope)
+	}
+	return `- First line: type(scope): brief description (max 72 chars)
+- Types: feat, fix, refactor, docs, chore`
+}
+
 // BuildCommitMessagePrompt builds the commit message prompt
-func BuildCommitMessagePrompt(issueID, content string) string {
+func BuildCommitMessagePrompt(issueID, content string, style CommitStyle) string {
 	return fmt.Sprintf(`Generate a git commit message for closing issue %s.
 
 Context:
 %s
 
 Requirements:
-- First line: type(scope): brief description (max 72 chars)
-- Types: feat, fix, refactor, docs, chore
+%s
 - Blank line after first line
 - Body: bullet points explaining key changes
 - Footer: Issue: #%s
 
 Output ONLY the commit message, no explanations.
-Do NOT wrap the output in code blocks or backticks.`, issueID, content, issueID)
+Do NOT wrap the output in code blocks or backticks.`, issueID, content, commitSubjectRules(style), issueID)
 }
 
 const analysisJSONSchema = `{
@@ -179,11 +315,17 @@ const analysisJSONSchema = `{
   "risk_assessment": "Overall risk assessment and considerations"
 }`
 
-// BuildAnalysisPromptJSON builds the analysis prompt for JSON output
-func BuildAnalysisPromptJSON(briefContent, briefPath string) string {
+// BuildAnalysisPromptJSON builds the analysis prompt for JSON output, framed for the
+// issue type
+func BuildAnalysisPromptJSON(briefContent, briefPath string, issueType model.IssueType) string {
+	focus := analysisFocusFor(issueType)
 	return fmt.Sprintf(`%s## Task
 Analyze this issue and provide structured analysis in JSON format.
 
+## Focus
+%s
+Use the "root_cause" field for the %s.
+
 Issue (%s):
 %s
 
@@ -199,7 +341,7 @@ Return a JSON object with the following structure:
 5. Do NOT wrap the JSON in code blocks - return raw JSON only
 6. Ensure valid JSON syntax (proper escaping of special characters in strings)
 
-Return ONLY the JSON object, no additional text.`, readOnlyConstraints, briefPath, briefContent, analysisJSONSchema)
+Return ONLY the JSON object, no additional text.`, readOnlyConstraints, focus.Guidance, strings.ToLower(focus.Heading), briefPath, briefContent, analysisJSONSchema)
 }
 
 // BuildPlanPromptWithOption builds the plan prompt with selected option context
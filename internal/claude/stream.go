@@ -0,0 +1,159 @@
+package claude
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// streamEvent is the subset of a stream-json line lfim reads
+type streamEvent struct {
+	Type      string `json:"type"`
+	Result    string `json:"result"`
+	IsError   bool   `json:"is_error"`
+	SessionID string `json:"session_id"`
+	// Set on the final "result" event
+	Usage        responseUsage `json:"usage"`
+	TotalCostUSD float64       `json:"total_cost_usd"`
+	Message      struct {
+		Content []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"content"`
+	} `json:"message"`
+	// Token deltas, present when the CLI emits partial messages
+	Event struct {
+		Delta struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"delta"`
+	} `json:"event"`
+}
+
+// partialSyncInterval is how often streamed output is synced to disk while deltas
+// arrive; whole messages are synced as they complete
+const partialSyncInterval = time.Second
+
+// RunAsyncStreaming is RunAsync for long analysis runs: output is streamed and each
+// piece of text is appended to partialPath as it arrives, so an interrupted run
+// leaves its partial output on disk. The caller removes partialPath once the final
+// result is saved. Like RunAsync, the call waits for a free slot and is killed when
+// ctx is cancelled or after c.Timeout.
+func (c *Client) RunAsyncStreaming(ctx context.Context, issueID, taskType, prompt, model, resumeSession, partialPath string, resultChan chan<- TaskResult) {
+	go func() {
+		if !c.acquire(ctx, issueID) {
+			resultChan <- cancelledResult(issueID, taskType)
+			return
+		}
+		defer c.release()
+
+		ctx, cancel := c.withTimeout(ctx)
+		defer cancel()
+
+		var extraArgs []string
+		if !c.ReadOnly && writableTasks[taskType] {
+			extraArgs = append(extraArgs, "--permission-mode", "acceptEdits")
+		}
+
+		start := time.Now()
+		result := c.runStreaming(ctx, prompt, model, resumeSession, partialPath, extraArgs...)
+		c.Metrics.Record(taskType, time.Since(start), result.Success)
+
+		result.IssueID = issueID
+		result.TaskType = taskType
+		result.TimedOut = errors.Is(ctx.Err(), context.DeadlineExceeded)
+		result.Cancelled = errors.Is(ctx.Err(), context.Canceled)
+		resultChan <- result
+	}()
+}
+
+// runStreaming runs Claude with stream-json output, appending text to partialPath.
+// Like run, it sets only the outcome fields of the TaskResult.
+func (c *Client) runStreaming(ctx context.Context, prompt, model, resumeSession, partialPath string, extraArgs ...string) TaskResult {
+	args := []string{"--output-format", "stream-json", "--verbose"}
+	args = append(args, extraArgs...)
+	if model != "" {
+		args = append(args, "--model", model)
+	}
+	if resumeSession != "" {
+		args = append(args, "--resume", resumeSession)
+	}
+	args = append(args, "-p", prompt)
+
+	cmd := c.CommandContext(ctx, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return TaskResult{Result: err.Error()}
+	}
+
+	partial, err := os.OpenFile(partialPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
+	if err != nil {
+		return TaskResult{Result: fmt.Sprintf("creating partial output: %v", err)}
+	}
+	defer partial.Close()
+
+	if err := cmd.Start(); err != nil {
+		return c.failure(ctx, err, "")
+	}
+
+	var final *streamEvent
+	sawDeltas := false
+	lastSync := time.Now()
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var event streamEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		switch event.Type {
+		case "stream_event":
+			if event.Event.Delta.Type == "text_delta" {
+				sawDeltas = true
+				_, _ = partial.WriteString(event.Event.Delta.Text)
+			}
+		case "assistant":
+			// Whole messages repeat the deltas when both are emitted
+			if sawDeltas {
+				continue
+			}
+			for _, block := range event.Message.Content {
+				if block.Type == "text" {
+					_, _ = partial.WriteString(block.Text + "\n")
+				}
+			}
+		case "result":
+			final = &event
+		}
+		if event.Type == "assistant" || time.Since(lastSync) >= partialSyncInterval {
+			_ = partial.Sync()
+			lastSync = time.Now()
+		}
+	}
+	_ = partial.Sync()
+
+	err = cmd.Wait()
+	errOutput := strings.TrimSpace(stderr.String())
+	if err != nil {
+		return c.failure(ctx, err, errOutput)
+	}
+	if final == nil {
+		return TaskResult{Result: "no result in Claude output", Stderr: errOutput}
+	}
+	usage := Response{Usage: final.Usage, TotalCostUSD: final.TotalCostUSD}.usage()
+	return TaskResult{
+		Success:   !final.IsError,
+		Result:    final.Result,
+		SessionID: final.SessionID,
+		Stderr:    errOutput,
+		Usage:     usage,
+	}
+}
@@ -0,0 +1,291 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the optional per-project config file in the project root.
+// Its values override the user-level config, except for userOnlyKeys.
+const ProjectFileName = ".lfim.yaml"
+
+// userOnlyKeys are the settings a project's .lfim.yaml may not set. The file is
+// committed with the repository, so anything in it that runs commands or lets
+// Claude edit files would apply to whoever clones the repository and starts lfim
+// in it; only the user can decide to trust a repository that far.
+var userOnlyKeys = []string{"hooks", "claude.command", "claude.extra_args", "claude.read_only"}
+
+// CloseMode controls what gets committed when an issue is closed
+type CloseMode string
+
+const (
+	// CloseModeStaged commits whatever is staged with an AI-generated message (default)
+	CloseModeStaged CloseMode = "staged"
+	// CloseModeIssueOnly commits only the issue's own files as a separate chore commit,
+	// leaving code changes for the user to commit
+	CloseModeIssueOnly CloseMode = "issue-only"
+	// CloseModePlan stages and commits exactly the files in the plan's Files Modified
+	// table, plus the issue's own files, with an AI-generated message
+	CloseModePlan CloseMode = "plan"
+)
+
+// Config holds user settings loaded from config.yaml
+type Config struct {
+	Close     CloseConfig     `yaml:"close"`
+	Claude    ClaudeConfig    `yaml:"claude"`
+	Commit    CommitConfig    `yaml:"commit"`
+	Implement ImplementConfig `yaml:"implement"`
+	UI        UIConfig        `yaml:"ui"`
+	Hooks     []HookConfig    `yaml:"hooks"`
+
+	// MaxAnalysisVersions caps the analysis_vN.md files kept per issue, deleting the
+	// oldest when a new version is saved; 0 (default) keeps all, and at least 2 are kept
+	MaxAnalysisVersions int `yaml:"max_analysis_versions"`
+}
+
+// UIConfig configures TUI behavior
+type UIConfig struct {
+	// WrapNavigation makes Up on the first issue jump to the last, and Down on the last to the first
+	WrapNavigation bool `yaml:"wrap_navigation"`
+	// CompactList shows one line per issue, without the summary line under the title
+	CompactList bool `yaml:"compact_list"`
+	// BulkConfirmThreshold is the most marked issues a bulk analyze/discard/delete (or
+	// lfim analyze) accepts with a plain y/n; above it the count must be typed. 0 always asks for the count, -1 never.
+	BulkConfirmThreshold int `yaml:"bulk_confirm_threshold"`
+}
+
+// DefaultBulkConfirmThreshold is the default for UIConfig.BulkConfirmThreshold
+const DefaultBulkConfirmThreshold = 5
+
+// CommitConvention selects the style of generated commit messages
+type CommitConvention string
+
+const (
+	CommitConventional CommitConvention = "conventional"
+	CommitGitmoji      CommitConvention = "gitmoji"
+	CommitPlain        CommitConvention = "plain"
+)
+
+// CommitScope selects how the scope of a generated commit message is derived
+type CommitScope string
+
+const (
+	// CommitScopeAuto lets Claude pick a scope from the changes
+	CommitScopeAuto CommitScope = "auto"
+	// CommitScopeType uses the issue type (feature, bug, refactor)
+	CommitScopeType CommitScope = "type"
+	// CommitScopeLabel uses the issue's first label, or its type when it has none
+	CommitScopeLabel CommitScope = "label"
+	// CommitScopeNone omits the scope
+	CommitScopeNone CommitScope = "none"
+)
+
+// CommitConfig configures AI-generated commit messages
+type CommitConfig struct {
+	Convention CommitConvention `yaml:"convention"`
+	Scope      CommitScope      `yaml:"scope"`
+}
+
+// ImplementConfig configures the implement flow
+type ImplementConfig struct {
+	// CreateBranch switches to the issue's own branch (issue/<id>-<title>), creating
+	// it if needed, before implementing
+	CreateBranch bool `yaml:"create_branch"`
+}
+
+// CloseConfig configures the close flow
+type CloseConfig struct {
+	Mode CloseMode `yaml:"mode"`
+}
+
+// LLMCommandEnv names the environment variable that overrides claude.command; any
+// words after the first replace claude.extra_args
+const LLMCommandEnv = "LFIM_LLM_CMD"
+
+// ClaudeConfig configures how Claude is invoked
+type ClaudeConfig struct {
+	// Command is the CLI to run instead of "claude", e.g. a wrapper or another
+	// provider's tool. It must accept Claude's -p, --output-format json, --model
+	// and --resume flags and print {"result": ..., "session_id": ...}. Like
+	// ExtraArgs, it is only read from the user config and LFIM_LLM_CMD.
+	Command string `yaml:"command"`
+	// ExtraArgs are passed to Command before lfim's own arguments
+	ExtraArgs []string `yaml:"extra_args"`
+	// ReadOnly forbids Claude from writing files during review and plan review.
+	// Disabling it lets Claude edit files directly: only do so in trusted repositories.
+	// It is only read from the user config, so a repository can't disable it for itself.
+	ReadOnly bool `yaml:"read_only"`
+	// Timeout kills a Claude call that runs longer than this (e.g. "5m"); 0 disables
+	// it. Implementation runs are not bounded by it.
+	Timeout time.Duration `yaml:"timeout"`
+	// MaxConcurrent is how many Claude tasks run at once; more wait in a queue.
+	// 0 removes the limit.
+	MaxConcurrent int `yaml:"max_concurrent"`
+	// LabelGuidance maps an issue label to extra instructions appended to the
+	// analysis and plan prompts of issues carrying it
+	LabelGuidance map[string]string `yaml:"label_guidance"`
+	// Models picks the model per task type: analyze, review, plan, plan-review,
+	// add-option, commit, update-changelog. Other tasks use the Claude CLI's default.
+	Models map[string]string `yaml:"models"`
+}
+
+// ModelFor returns the model configured for a task type, or "" for the CLI default
+func (c ClaudeConfig) ModelFor(taskType string) string {
+	return c.Models[taskType]
+}
+
+// HookConfig runs a shell command or POSTs to a URL when an issue changes status.
+// Exactly one of Command and URL must be set. Hooks are only read from the user config.
+type HookConfig struct {
+	// Command is run with sh -c in the project root. Issue metadata is passed as
+	// LFIM_* environment variables and as JSON on stdin.
+	Command string `yaml:"command,omitempty"`
+	// URL receives the same JSON payload as an HTTP POST
+	URL string `yaml:"url,omitempty"`
+	// Statuses limits the hook to transitions into these statuses; empty means all
+	Statuses []string `yaml:"statuses,omitempty"`
+}
+
+// Default returns the configuration used when no config file exists
+func Default() *Config {
+	return &Config{
+		Close: CloseConfig{Mode: CloseModeStaged},
+		Claude: ClaudeConfig{
+			Command:       "claude",
+			ReadOnly:      true,
+			Timeout:       2 * time.Minute,
+			MaxConcurrent: 2,
+			Models:        map[string]string{"commit": "haiku", "update-changelog": "haiku"},
+		},
+		Commit: CommitConfig{Convention: CommitConventional, Scope: CommitScopeAuto},
+		UI:     UIConfig{BulkConfirmThreshold: DefaultBulkConfirmThreshold},
+	}
+}
+
+// UserPath returns the path of the user-level config file (e.g. ~/.config/lfim/config.yaml)
+func UserPath() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+// Load reads the user-level config and overlays the project's .lfim.yaml if present.
+// Missing files are not an error; malformed ones are, as is a project file setting
+// one of userOnlyKeys.
+func Load(projectRoot string) (*Config, error) {
+	cfg := Default()
+
+	if projectRoot == "" {
+		projectRoot, _ = os.Getwd()
+	}
+
+	projectPath := filepath.Join(projectRoot, ProjectFileName)
+	for _, path := range []string{UserPath(), projectPath} {
+		if path == "" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading config: %w", err)
+		}
+		if path == projectPath {
+			if err := checkProjectKeys(path, data); err != nil {
+				return nil, err
+			}
+		}
+		if err := yaml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+
+	if fields := strings.Fields(os.Getenv(LLMCommandEnv)); len(fields) > 0 {
+		cfg.Claude.Command = fields[0]
+		cfg.Claude.ExtraArgs = fields[1:]
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// checkProjectKeys rejects a project config that sets any of userOnlyKeys
+func checkProjectKeys(path string, data []byte) error {
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	for _, key := range userOnlyKeys {
+		if hasKey(raw, key) {
+			return fmt.Errorf("%s sets %s, which is only read from the user config (%s)", path, key, UserPath())
+		}
+	}
+	return nil
+}
+
+// hasKey reports whether the dotted key (e.g. "claude.command") is set in m
+func hasKey(m map[string]interface{}, key string) bool {
+	name, rest, nested := strings.Cut(key, ".")
+	value, ok := m[name]
+	if !ok || !nested {
+		return ok
+	}
+	sub, ok := value.(map[string]interface{})
+	return ok && hasKey(sub, rest)
+}
+
+// validate checks enum-like values
+func (c *Config) validate() error {
+	switch c.Close.Mode {
+	case "":
+		c.Close.Mode = CloseModeStaged
+	case CloseModeStaged, CloseModeIssueOnly, CloseModePlan:
+	default:
+		return fmt.Errorf("invalid close.mode %q (expected %q, %q or %q)", c.Close.Mode, CloseModeStaged, CloseModeIssueOnly, CloseModePlan)
+	}
+	switch c.Commit.Convention {
+	case "":
+		c.Commit.Convention = CommitConventional
+	case CommitConventional, CommitGitmoji, CommitPlain:
+	default:
+		return fmt.Errorf("invalid commit.convention %q (expected %q, %q or %q)", c.Commit.Convention, CommitConventional, CommitGitmoji, CommitPlain)
+	}
+	switch c.Commit.Scope {
+	case "":
+		c.Commit.Scope = CommitScopeAuto
+	case CommitScopeAuto, CommitScopeType, CommitScopeLabel, CommitScopeNone:
+	default:
+		return fmt.Errorf("invalid commit.scope %q (expected %q, %q, %q or %q)", c.Commit.Scope, CommitScopeAuto, CommitScopeType, CommitScopeLabel, CommitScopeNone)
+	}
+	if c.Claude.Command == "" {
+		c.Claude.Command = "claude"
+	}
+	if c.Claude.Timeout < 0 {
+		return fmt.Errorf("invalid claude.timeout %s (must not be negative)", c.Claude.Timeout)
+	}
+	if c.Claude.MaxConcurrent < 0 {
+		return fmt.Errorf("invalid claude.max_concurrent %d (expected 0 or more)", c.Claude.MaxConcurrent)
+	}
+	if c.MaxAnalysisVersions < 0 {
+		return fmt.Errorf("invalid max_analysis_versions %d (must not be negative)", c.MaxAnalysisVersions)
+	}
+	if c.UI.BulkConfirmThreshold < -1 {
+		return fmt.Errorf("invalid ui.bulk_confirm_threshold %d (expected -1 or more)", c.UI.BulkConfirmThreshold)
+	}
+	for i, h := range c.Hooks {
+		if (h.Command == "") == (h.URL == "") {
+			return fmt.Errorf("hooks[%d]: exactly one of command or url must be set", i)
+		}
+	}
+	return nil
+}
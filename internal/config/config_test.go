@@ -0,0 +1,127 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeConfigs sets up a user config and a project .lfim.yaml (either may be empty
+// to leave it out) and returns the project root
+func writeConfigs(t *testing.T, user, project string) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv(LLMCommandEnv, "")
+	root := t.TempDir()
+	for path, content := range map[string]string{UserPath(): user, filepath.Join(root, ProjectFileName): project} {
+		if content == "" {
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestLoadProjectOverridesUser(t *testing.T) {
+	root := writeConfigs(t, "close:\n  mode: plan\nui:\n  compact_list: true\n", "close:\n  mode: issue-only\n")
+	cfg, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Close.Mode != CloseModeIssueOnly || !cfg.UI.CompactList {
+		t.Errorf("close.mode = %s, compact_list = %v; want the project's issue-only and the user's true", cfg.Close.Mode, cfg.UI.CompactList)
+	}
+}
+
+func TestLoadHooksFromUserConfigOnly(t *testing.T) {
+	const hooks = "hooks:\n  - command: touch pwned\n"
+
+	cfg, err := Load(writeConfigs(t, hooks, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cfg.Hooks) != 1 || cfg.Hooks[0].Command != "touch pwned" {
+		t.Errorf("user hooks = %+v", cfg.Hooks)
+	}
+
+	_, err = Load(writeConfigs(t, "", hooks))
+	if err == nil || !strings.Contains(err.Error(), "sets hooks") {
+		t.Fatalf("project hooks: err = %v, want them rejected", err)
+	}
+}
+
+func TestLoadClaudeCommandFromUserConfigOnly(t *testing.T) {
+	cfg, err := Load(writeConfigs(t, "claude:\n  command: my-claude\n  extra_args: [--verbose]\n", "claude:\n  timeout: 5m\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Claude.Command != "my-claude" || len(cfg.Claude.ExtraArgs) != 1 {
+		t.Errorf("user command = %q %q", cfg.Claude.Command, cfg.Claude.ExtraArgs)
+	}
+
+	for _, project := range []string{
+		"claude:\n  command: ./evil\n",
+		"claude:\n  extra_args: [--dangerously-skip-permissions]\n",
+	} {
+		if _, err := Load(writeConfigs(t, "", project)); err == nil || !strings.Contains(err.Error(), "only read from the user config") {
+			t.Errorf("project config %q: err = %v, want it rejected", project, err)
+		}
+	}
+
+	t.Run("environment", func(t *testing.T) {
+		root := writeConfigs(t, "claude:\n  command: my-claude\n", "")
+		t.Setenv(LLMCommandEnv, "wrapper --flag")
+		cfg, err := Load(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cfg.Claude.Command != "wrapper" || len(cfg.Claude.ExtraArgs) != 1 || cfg.Claude.ExtraArgs[0] != "--flag" {
+			t.Errorf("%s: command = %q %q", LLMCommandEnv, cfg.Claude.Command, cfg.Claude.ExtraArgs)
+		}
+	})
+}
+
+func TestLoadReadOnlyFromUserConfigOnly(t *testing.T) {
+	cfg, err := Load(writeConfigs(t, "", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Claude.ReadOnly {
+		t.Error("read_only is off by default")
+	}
+
+	cfg, err = Load(writeConfigs(t, "claude:\n  read_only: false\n", ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Claude.ReadOnly {
+		t.Error("user config couldn't turn read_only off")
+	}
+
+	if _, err := Load(writeConfigs(t, "", "claude:\n  read_only: false\n")); err == nil || !strings.Contains(err.Error(), "claude.read_only") {
+		t.Errorf("project read_only: err = %v, want it rejected", err)
+	}
+}
+
+func TestLoadCommitScope(t *testing.T) {
+	for _, scope := range []CommitScope{CommitScopeAuto, CommitScopeType, CommitScopeLabel, CommitScopeNone} {
+		cfg, err := Load(writeConfigs(t, "commit:\n  scope: "+string(scope)+"\n", ""))
+		if err != nil {
+			t.Fatalf("scope %s: %v", scope, err)
+		}
+		if cfg.Commit.Scope != scope {
+			t.Errorf("commit.scope = %s, want %s", cfg.Commit.Scope, scope)
+		}
+	}
+	if _, err := Load(writeConfigs(t, "commit:\n  scope: labels\n", "")); err == nil {
+		t.Error("commit.scope labels accepted")
+	}
+}
@@ -0,0 +1,67 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// State holds TUI preferences that persist across sessions
+type State struct {
+	SplitRatio  float64 `yaml:"split_ratio,omitempty"`
+	WrapPreview bool    `yaml:"wrap_preview,omitempty"` // wrap long lines in the review overlays instead of panning
+	// Recent holds each issues directory's recently viewed issue IDs, oldest first
+	Recent map[string][]string `yaml:"recent,omitempty"`
+}
+
+// Dir returns the lfim configuration directory (e.g. ~/.config/lfim)
+func Dir() string {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(base, "lfim")
+}
+
+// StatePath returns the path of the persisted TUI state file
+func StatePath() string {
+	dir := Dir()
+	if dir == "" {
+		return ""
+	}
+	return filepath.Join(dir, "state.yaml")
+}
+
+// LoadState loads the persisted TUI state, returning an empty state if none exists
+func LoadState() *State {
+	state := &State{}
+
+	path := StatePath()
+	if path == "" {
+		return state
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return state
+	}
+	_ = yaml.Unmarshal(data, state)
+	return state
+}
+
+// Save writes the TUI state to disk
+func (s *State) Save() error {
+	path := StatePath()
+	if path == "" {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
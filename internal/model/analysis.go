@@ -3,6 +3,7 @@ package model
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 )
 
 // AnalysisOption represents a single implementation option
@@ -109,3 +110,66 @@ func ParseAnalysis(data []byte) (*Analysis, error) {
 	}
 	return &analysis, nil
 }
+
+// ToMarkdown renders the analysis as human-readable markdown
+func (a *Analysis) ToMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString("## Summary\n\n")
+	sb.WriteString(a.Summary)
+	sb.WriteString("\n\n")
+
+	if a.RootCause != "" {
+		sb.WriteString("## Root Cause / Scope\n\n")
+		sb.WriteString(a.RootCause)
+		sb.WriteString("\n\n")
+	}
+
+	if len(a.Options) > 0 {
+		sb.WriteString("## Options\n\n")
+		selected := a.GetSelectedOption()
+		for _, opt := range a.Options {
+			sb.WriteString(fmt.Sprintf("### %s\n\n", opt.Title))
+			var badges []string
+			if opt.Recommended {
+				badges = append(badges, "recommended")
+			}
+			if selected != nil && selected.ID == opt.ID && a.SelectedOptionID != "" {
+				badges = append(badges, "selected")
+			}
+			if len(badges) > 0 {
+				sb.WriteString(fmt.Sprintf("_%s_\n\n", strings.Join(badges, ", ")))
+			}
+			if opt.Description != "" {
+				sb.WriteString(opt.Description)
+				sb.WriteString("\n\n")
+			}
+			if len(opt.Pros) > 0 {
+				sb.WriteString("**Pros**\n")
+				for _, pro := range opt.Pros {
+					sb.WriteString("- " + pro + "\n")
+				}
+				sb.WriteString("\n")
+			}
+			if len(opt.Cons) > 0 {
+				sb.WriteString("**Cons**\n")
+				for _, con := range opt.Cons {
+					sb.WriteString("- " + con + "\n")
+				}
+				sb.WriteString("\n")
+			}
+			if opt.Details != "" {
+				sb.WriteString(opt.Details)
+				sb.WriteString("\n\n")
+			}
+		}
+	}
+
+	if a.RiskAssessment != "" {
+		sb.WriteString("## Risk Assessment\n\n")
+		sb.WriteString(a.RiskAssessment)
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimRight(sb.String(), "\n") + "\n"
+}
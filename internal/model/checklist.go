@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// checklistRegex matches a GitHub-style task list item: indent, bullet, [ ] or [x], text
+var checklistRegex = regexp.MustCompile(`^(\s*)[-*+] \[([ xX])\](?:\s+(.*))?$`)
+
+// ChecklistItem is a single "- [ ]" / "- [x]" line in a markdown document
+type ChecklistItem struct {
+	Line  int    // 0-based line number in the parsed content
+	Depth int    // nesting level, 0 for top-level items
+	Text  string // item text without the checkbox
+	Done  bool
+}
+
+// ParseChecklist extracts task list items from markdown content, skipping fenced
+// code blocks. Nesting depth follows indentation relative to enclosing items.
+func ParseChecklist(content string) []ChecklistItem {
+	var items []ChecklistItem
+	var indents []int // indent widths of the enclosing items
+	inFence := false
+
+	for i, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+
+		m := checklistRegex.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		indent := indentWidth(m[1])
+		for len(indents) > 0 && indents[len(indents)-1] >= indent {
+			indents = indents[:len(indents)-1]
+		}
+		items = append(items, ChecklistItem{
+			Line:  i,
+			Depth: len(indents),
+			Text:  strings.TrimSpace(m[3]),
+			Done:  m[2] != " ",
+		})
+		indents = append(indents, indent)
+	}
+	return items
+}
+
+// indentWidth returns the display width of leading whitespace (tabs count as 4)
+func indentWidth(indent string) int {
+	width := 0
+	for _, r := range indent {
+		if r == '\t' {
+			width += 4
+		} else {
+			width++
+		}
+	}
+	return width
+}
+
+// ChecklistProgress returns the number of completed items and the total
+func ChecklistProgress(items []ChecklistItem) (done, total int) {
+	for _, item := range items {
+		if item.Done {
+			done++
+		}
+	}
+	return done, len(items)
+}
+
+// ToggleChecklistLine flips the checkbox on the given line, leaving the rest of the
+// content untouched
+func ToggleChecklistLine(content string, line int) (string, error) {
+	lines := strings.Split(content, "\n")
+	if line < 0 || line >= len(lines) {
+		return "", fmt.Errorf("line %d out of range", line+1)
+	}
+
+	loc := checklistRegex.FindStringSubmatchIndex(lines[line])
+	if loc == nil {
+		return "", fmt.Errorf("line %d is not a checklist item", line+1)
+	}
+
+	// loc[4]:loc[5] is the checkbox state character
+	mark := "x"
+	if lines[line][loc[4]:loc[5]] != " " {
+		mark = " "
+	}
+	lines[line] = lines[line][:loc[4]] + mark + lines[line][loc[5]:]
+	return strings.Join(lines, "\n"), nil
+}
@@ -2,12 +2,17 @@ package model
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 )
 
 // IssueIndex represents the collection of all issues
 type IssueIndex struct {
 	Issues []*Issue
+
+	// Cached breakdowns, computed on first use and reset by AddIssue/UpdateIssue
+	statusCounts map[IssueStatus]int
+	typeCounts   map[IssueType]int
 }
 
 // NewIssueIndex creates a new empty issue index
@@ -37,6 +42,7 @@ func (idx *IssueIndex) GetNextID() string {
 // AddIssue adds an issue to the index
 func (idx *IssueIndex) AddIssue(issue *Issue) {
 	idx.Issues = append(idx.Issues, issue)
+	idx.resetCounts()
 }
 
 // GetIssue finds an issue by ID
@@ -49,19 +55,37 @@ func (idx *IssueIndex) GetIssue(id string) *Issue {
 	return nil
 }
 
-// UpdateIssue updates an existing issue in the index
+// UpdateIssue updates an existing issue in the index. The later of the two Updated
+// times is kept, since an issue loaded from its brief doesn't carry it.
 func (idx *IssueIndex) UpdateIssue(updated *Issue) {
 	for i, issue := range idx.Issues {
 		if issue.ID == updated.ID {
+			if issue.Updated.After(updated.Updated) {
+				updated.Updated = issue.Updated
+			}
 			idx.Issues[i] = updated
+			idx.resetCounts()
 			return
 		}
 	}
 }
 
-// GetActiveIssues returns issues that are not closed or invalid
+// RemoveIssue removes an issue from the index, reporting whether it was present
+func (idx *IssueIndex) RemoveIssue(id string) bool {
+	for i, issue := range idx.Issues {
+		if issue.ID == id {
+			idx.Issues = append(idx.Issues[:i], idx.Issues[i+1:]...)
+			idx.resetCounts()
+			return true
+		}
+	}
+	return false
+}
+
+// GetActiveIssues returns issues that are not closed or invalid, implemented ones
+// awaiting close included
 func (idx *IssueIndex) GetActiveIssues() []*Issue {
-	return idx.FilterByStatus(StatusOpen, StatusAnalyzed, StatusPlanned)
+	return idx.FilterByStatus(StatusOpen, StatusAnalyzed, StatusPlanned, StatusImplemented)
 }
 
 // GetClosedIssues returns issues that are closed or invalid
@@ -69,6 +93,18 @@ func (idx *IssueIndex) GetClosedIssues() []*Issue {
 	return idx.FilterByStatus(StatusClosed, StatusInvalid)
 }
 
+// LastClosed returns the closed or invalid issue closed most recently, or nil if
+// none has a close time
+func (idx *IssueIndex) LastClosed() *Issue {
+	var last *Issue
+	for _, issue := range idx.GetClosedIssues() {
+		if !issue.Closed.IsZero() && (last == nil || issue.Closed.After(last.Closed)) {
+			last = issue
+		}
+	}
+	return last
+}
+
 // FilterByStatus returns issues matching the given statuses
 func (idx *IssueIndex) FilterByStatus(statuses ...IssueStatus) []*Issue {
 	statusSet := make(map[IssueStatus]bool)
@@ -85,10 +121,70 @@ func (idx *IssueIndex) FilterByStatus(statuses ...IssueStatus) []*Issue {
 	return filtered
 }
 
-// SortByCreated sorts issues by creation date (newest first)
+// FilterByLabel returns issues carrying label
+func (idx *IssueIndex) FilterByLabel(label string) []*Issue {
+	var result []*Issue
+	for _, issue := range idx.Issues {
+		if issue.HasLabel(label) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// Labels returns every label used in the index, sorted
+func (idx *IssueIndex) Labels() []string {
+	seen := make(map[string]bool)
+	var labels []string
+	for _, issue := range idx.Issues {
+		for _, label := range issue.Labels {
+			if !seen[label] {
+				seen[label] = true
+				labels = append(labels, label)
+			}
+		}
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+// CountByStatus returns the number of issues per status
+func (idx *IssueIndex) CountByStatus() map[IssueStatus]int {
+	if idx.statusCounts == nil {
+		idx.statusCounts = make(map[IssueStatus]int)
+		for _, issue := range idx.Issues {
+			idx.statusCounts[issue.Status]++
+		}
+	}
+	return idx.statusCounts
+}
+
+// CountByType returns the number of issues per type
+func (idx *IssueIndex) CountByType() map[IssueType]int {
+	if idx.typeCounts == nil {
+		idx.typeCounts = make(map[IssueType]int)
+		for _, issue := range idx.Issues {
+			idx.typeCounts[issue.Type]++
+		}
+	}
+	return idx.typeCounts
+}
+
+// resetCounts drops the cached breakdowns after the issue list changes
+func (idx *IssueIndex) resetCounts() {
+	idx.statusCounts = nil
+	idx.typeCounts = nil
+}
+
+// SortByCreated sorts issues by creation time (newest first), by descending ID for
+// equal times (e.g. date-only entries written by older versions)
 func (idx *IssueIndex) SortByCreated() {
-	sort.Slice(idx.Issues, func(i, j int) bool {
-		return idx.Issues[i].Created.After(idx.Issues[j].Created)
+	sort.SliceStable(idx.Issues, func(i, j int) bool {
+		a, b := idx.Issues[i], idx.Issues[j]
+		if !a.Created.Equal(b.Created) {
+			return a.Created.After(b.Created)
+		}
+		return a.ID > b.ID
 	})
 }
 
@@ -99,6 +195,71 @@ func (idx *IssueIndex) SortByID() {
 	})
 }
 
+// Blockers returns the IDs of the issues blocking id that are still active, from
+// its blocked_by list and from other issues' blocks lists, sorted
+func (idx *IssueIndex) Blockers(id string) []string {
+	issue := idx.GetIssue(id)
+	if issue == nil {
+		return nil
+	}
+	var blockers []string
+	add := func(blocker *Issue) {
+		if blocker != nil && blocker.ID != id && blocker.Status.IsActive() && !slices.Contains(blockers, blocker.ID) {
+			blockers = append(blockers, blocker.ID)
+		}
+	}
+	for _, blockerID := range issue.BlockedBy {
+		add(idx.GetIssue(blockerID))
+	}
+	for _, other := range idx.Issues {
+		if slices.Contains(other.Blocks, id) {
+			add(other)
+		}
+	}
+	sort.Strings(blockers)
+	return blockers
+}
+
+// IsBlocked reports whether any issue blocking id isn't closed or discarded yet
+func (idx *IssueIndex) IsBlocked(id string) bool {
+	return len(idx.Blockers(id)) > 0
+}
+
+// SortByUpdated sorts issues by their last update (most recent first), by
+// descending ID for equal times
+func (idx *IssueIndex) SortByUpdated() {
+	sort.SliceStable(idx.Issues, func(i, j int) bool {
+		a, b := idx.Issues[i], idx.Issues[j]
+		if !a.Updated.Equal(b.Updated) {
+			return a.Updated.After(b.Updated)
+		}
+		return a.ID > b.ID
+	})
+}
+
+// SortByPriority sorts issues from critical to low, by ID within a priority
+func (idx *IssueIndex) SortByPriority() {
+	sort.SliceStable(idx.Issues, func(i, j int) bool {
+		a, b := idx.Issues[i], idx.Issues[j]
+		if a.Priority.Rank() != b.Priority.Rank() {
+			return a.Priority.Rank() > b.Priority.Rank()
+		}
+		return a.ID < b.ID
+	})
+}
+
+// SortByStatus sorts issues along the lifecycle (open first, invalid last), by ID
+// within a status
+func (idx *IssueIndex) SortByStatus() {
+	sort.SliceStable(idx.Issues, func(i, j int) bool {
+		a, b := idx.Issues[i], idx.Issues[j]
+		if a.Status.Rank() != b.Status.Rank() {
+			return a.Status.Rank() < b.Status.Rank()
+		}
+		return a.ID < b.ID
+	})
+}
+
 // ToYAML returns a map for YAML serialization
 func (idx *IssueIndex) ToYAML() map[string]interface{} {
 	issues := make([]map[string]interface{}, 0, len(idx.Issues))
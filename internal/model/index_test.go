@@ -0,0 +1,42 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCountByStatus(t *testing.T) {
+	idx := NewIssueIndex()
+	for _, issue := range []*Issue{
+		{ID: "0001", Status: StatusOpen, Type: TypeBug},
+		{ID: "0002", Status: StatusOpen, Type: TypeFeature},
+		{ID: "0003", Status: StatusPlanned, Type: TypeBug},
+		{ID: "0004", Status: StatusClosed, Type: TypeRefactor},
+	} {
+		idx.AddIssue(issue)
+	}
+
+	if got, want := idx.CountByStatus(), map[IssueStatus]int{StatusOpen: 2, StatusPlanned: 1, StatusClosed: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountByStatus() = %v, want %v", got, want)
+	}
+	if got, want := idx.CountByType(), map[IssueType]int{TypeBug: 2, TypeFeature: 1, TypeRefactor: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("CountByType() = %v, want %v", got, want)
+	}
+
+	// The cached counts follow every change to the list
+	idx.UpdateIssue(&Issue{ID: "0001", Status: StatusAnalyzed, Type: TypeBug})
+	if got, want := idx.CountByStatus(), map[IssueStatus]int{StatusOpen: 1, StatusAnalyzed: 1, StatusPlanned: 1, StatusClosed: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after UpdateIssue: CountByStatus() = %v, want %v", got, want)
+	}
+	idx.RemoveIssue("0004")
+	if got, want := idx.CountByType(), map[IssueType]int{TypeBug: 2, TypeFeature: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("after RemoveIssue: CountByType() = %v, want %v", got, want)
+	}
+	if got := idx.CountByStatus()[StatusClosed]; got != 0 {
+		t.Errorf("after RemoveIssue: %d closed, want 0", got)
+	}
+	idx.AddIssue(&Issue{ID: "0005", Status: StatusOpen, Type: TypeFeature})
+	if got := idx.CountByStatus()[StatusOpen]; got != 2 {
+		t.Errorf("after AddIssue: %d open, want 2", got)
+	}
+}
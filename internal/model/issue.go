@@ -1,6 +1,9 @@
 package model
 
 import (
+	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/lunit-heesungyang/issue-manager/internal/ui"
@@ -28,6 +31,21 @@ func (s IssueStatus) IsClosed() bool {
 	return s == StatusClosed || s == StatusInvalid
 }
 
+// Valid reports whether s is a known lifecycle status
+func (s IssueStatus) Valid() bool {
+	switch s {
+	case StatusOpen, StatusAnalyzed, StatusPlanned, StatusImplemented, StatusClosed, StatusInvalid:
+		return true
+	}
+	return false
+}
+
+// Rank returns the position of s in the lifecycle, from open (0) to invalid (5),
+// or -1 if unknown
+func (s IssueStatus) Rank() int {
+	return slices.Index([]IssueStatus{StatusOpen, StatusAnalyzed, StatusPlanned, StatusImplemented, StatusClosed, StatusInvalid}, s)
+}
+
 // IssueType represents the category of an issue
 type IssueType string
 
@@ -37,6 +55,15 @@ const (
 	TypeRefactor IssueType = "refactor"
 )
 
+// Valid reports whether t is a known issue type
+func (t IssueType) Valid() bool {
+	switch t {
+	case TypeFeature, TypeBug, TypeRefactor:
+		return true
+	}
+	return false
+}
+
 // Icon returns the display emoji for this issue type
 func (t IssueType) Icon() string {
 	switch t {
@@ -51,42 +78,195 @@ func (t IssueType) Icon() string {
 	}
 }
 
+// IssuePriority ranks how urgently an issue should be tackled
+type IssuePriority string
+
+const (
+	PriorityLow      IssuePriority = "low"
+	PriorityMedium   IssuePriority = "medium"
+	PriorityHigh     IssuePriority = "high"
+	PriorityCritical IssuePriority = "critical"
+)
+
+// priorityOrder lists priorities from lowest to highest
+var priorityOrder = []IssuePriority{PriorityLow, PriorityMedium, PriorityHigh, PriorityCritical}
+
+// ParsePriority converts a frontmatter/index value to a priority, defaulting to
+// medium when the value is missing or unknown
+func ParsePriority(s string) IssuePriority {
+	p := IssuePriority(s)
+	if p.Valid() {
+		return p
+	}
+	return PriorityMedium
+}
+
+// Valid reports whether p is a known priority
+func (p IssuePriority) Valid() bool {
+	return p.Rank() >= 0
+}
+
+// Rank returns the position of p from low (0) to critical (3), or -1 if unknown
+func (p IssuePriority) Rank() int {
+	for i, candidate := range priorityOrder {
+		if candidate == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// Bump returns the priority delta steps away from p, clamped to low..critical
+func (p IssuePriority) Bump(delta int) IssuePriority {
+	rank := p.Rank()
+	if rank < 0 {
+		rank = PriorityMedium.Rank()
+	}
+	rank = min(max(rank+delta, 0), len(priorityOrder)-1)
+	return priorityOrder[rank]
+}
+
+// Marker returns the list marker for this priority, or "" for medium
+func (p IssuePriority) Marker() string {
+	switch p {
+	case PriorityCritical:
+		return ui.IconPriorityCritical
+	case PriorityHigh:
+		return ui.IconPriorityHigh
+	case PriorityLow:
+		return ui.IconPriorityLow
+	default:
+		return ""
+	}
+}
+
 // Issue represents a single issue
 type Issue struct {
-	ID            string      `yaml:"id"`
-	Title         string      `yaml:"title"`
-	Type          IssueType   `yaml:"type"`
-	Status        IssueStatus `yaml:"status"`
-	Created       time.Time   `yaml:"created"`
-	Content       string      `yaml:"-"` // Not stored in index.yaml
-	DiscardReason string      `yaml:"discard_reason,omitempty"`
+	ID            string        `yaml:"id" json:"id"`
+	Title         string        `yaml:"title" json:"title"`
+	Type          IssueType     `yaml:"type" json:"type"`
+	Status        IssueStatus   `yaml:"status" json:"status"`
+	Priority      IssuePriority `yaml:"priority" json:"priority"`
+	Labels        []string      `yaml:"labels,omitempty" json:"labels,omitempty"`
+	Blocks        []string      `yaml:"blocks,omitempty" json:"blocks,omitempty"`         // IDs of issues this one blocks
+	BlockedBy     []string      `yaml:"blocked_by,omitempty" json:"blocked_by,omitempty"` // IDs of issues blocking this one
+	Created       time.Time     `yaml:"created" json:"created"`
+	Updated       time.Time     `yaml:"updated,omitempty" json:"updated,omitzero"` // last change to the brief, status, analysis or plan; kept in index.yaml only
+	Closed        time.Time     `yaml:"closed,omitempty" json:"closed,omitzero"`   // when last closed or discarded
+	Content       string        `yaml:"-" json:"content,omitempty"`                // Not stored in index.yaml
+	DiscardReason string        `yaml:"discard_reason,omitempty" json:"discard_reason,omitempty"`
+	DuplicateOf   string        `yaml:"duplicate_of,omitempty" json:"duplicate_of,omitempty"` // canonical issue ID when merged
 }
 
 // ToIndexEntry returns a map for index.yaml serialization
 func (i *Issue) ToIndexEntry() map[string]interface{} {
-	return map[string]interface{}{
-		"id":      i.ID,
-		"title":   i.Title,
-		"type":    string(i.Type),
-		"status":  string(i.Status),
-		"created": i.Created.Format("2006-01-02"),
+	entry := map[string]interface{}{
+		"id":       i.ID,
+		"title":    i.Title,
+		"type":     string(i.Type),
+		"status":   string(i.Status),
+		"priority": string(i.Priority),
+		"created":  i.Created.Format(time.RFC3339),
+	}
+	if len(i.Labels) > 0 {
+		entry["labels"] = i.Labels
+	}
+	if len(i.Blocks) > 0 {
+		entry["blocks"] = i.Blocks
 	}
+	if len(i.BlockedBy) > 0 {
+		entry["blocked_by"] = i.BlockedBy
+	}
+	if !i.Updated.IsZero() {
+		entry["updated"] = i.Updated.Format(time.RFC3339)
+	}
+	if !i.Closed.IsZero() {
+		entry["closed"] = i.Closed.Format(time.RFC3339)
+	}
+	return entry
 }
 
 // ToFrontmatter returns a map for brief.md frontmatter
 func (i *Issue) ToFrontmatter() map[string]interface{} {
 	fm := map[string]interface{}{
-		"title":  i.Title,
-		"type":   string(i.Type),
-		"status": string(i.Status),
-		"date":   i.Created.Format("2006-01-02"),
+		"title":    i.Title,
+		"type":     string(i.Type),
+		"status":   string(i.Status),
+		"priority": string(i.Priority),
+		"date":     i.Created.Format(time.RFC3339),
 	}
 	if i.DiscardReason != "" {
 		fm["discard_reason"] = i.DiscardReason
 	}
+	if i.DuplicateOf != "" {
+		fm["duplicate_of"] = i.DuplicateOf
+	}
+	if !i.Closed.IsZero() {
+		fm["closed"] = i.Closed.Format(time.RFC3339)
+	}
+	if len(i.Labels) > 0 {
+		fm["labels"] = i.Labels
+	}
+	if len(i.Blocks) > 0 {
+		fm["blocks"] = i.Blocks
+	}
+	if len(i.BlockedBy) > 0 {
+		fm["blocked_by"] = i.BlockedBy
+	}
 	return fm
 }
 
+// TimeAgo renders how long before now t was, e.g. "just now", "5m ago", "2h ago",
+// "3d ago", "2w ago", "4mo ago"
+func TimeAgo(t, now time.Time) string {
+	d := now.Sub(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	}
+	days := int(d.Hours() / 24)
+	switch {
+	case days < 14:
+		return fmt.Sprintf("%dd ago", days)
+	case days < 60:
+		return fmt.Sprintf("%dw ago", days/7)
+	case days < 365:
+		return fmt.Sprintf("%dmo ago", days/30)
+	}
+	return fmt.Sprintf("%dy ago", days/365)
+}
+
+// HasLabel reports whether the issue carries label
+func (i *Issue) HasLabel(label string) bool {
+	return slices.Contains(i.Labels, label)
+}
+
+// BriefSummary returns the first line of a brief's text: the first non-empty line
+// that isn't frontmatter, a markdown header or an HTML comment
+func BriefSummary(content string) string {
+	lines := strings.Split(content, "\n")
+	if len(lines) > 0 && strings.TrimSpace(lines[0]) == "---" {
+		for i := 1; i < len(lines); i++ {
+			if strings.TrimSpace(lines[i]) == "---" {
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "<!--") {
+			continue
+		}
+		return line
+	}
+	return ""
+}
+
 // StatusIcon returns the display icon for this issue's status
 func (i *Issue) StatusIcon() string {
 	switch i.Status {
@@ -106,3 +286,48 @@ func (i *Issue) StatusIcon() string {
 		return ui.IconStatusUnknown
 	}
 }
+
+// ProgressPercent returns a coarse completion estimate from the lifecycle stage
+func (i *Issue) ProgressPercent() int {
+	return i.Status.progressPercent()
+}
+
+// ProgressPercentWithChecklist refines ProgressPercent with checklist completion,
+// moving the estimate toward the next stage as items are ticked off
+func (i *Issue) ProgressPercentWithChecklist(done, total int) int {
+	base := i.ProgressPercent()
+	if total == 0 || i.Status.IsClosed() {
+		return base
+	}
+	next := i.Status.nextStage().progressPercent()
+	return base + (next-base)*done/total
+}
+
+func (s IssueStatus) progressPercent() int {
+	switch s {
+	case StatusAnalyzed:
+		return 33
+	case StatusPlanned:
+		return 66
+	case StatusImplemented:
+		return 90
+	case StatusClosed:
+		return 100
+	default:
+		return 0
+	}
+}
+
+// nextStage returns the status that follows s in the lifecycle
+func (s IssueStatus) nextStage() IssueStatus {
+	switch s {
+	case StatusOpen:
+		return StatusAnalyzed
+	case StatusAnalyzed:
+		return StatusPlanned
+	case StatusPlanned:
+		return StatusImplemented
+	default:
+		return StatusClosed
+	}
+}
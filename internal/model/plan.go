@@ -0,0 +1,288 @@
+package model
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// planTaskRegex matches a numbered task: "1. Title" or "1) Title"
+	planTaskRegex = regexp.MustCompile(`^(\d+)[.)]\s+(.*)$`)
+	// planTaskHeadingRegex matches a task written as a heading: "### 1. Title", "### Task 2: Title"
+	planTaskHeadingRegex = regexp.MustCompile(`^#{3,4}\s+(?:Task\s+)?(\d+)[.):]?\s*(.*)$`)
+	// planFieldRegex matches a task sub-bullet such as "- File: path" or "- **Changes**: text"
+	planFieldRegex = regexp.MustCompile(`^[-*+]\s+(?:\*\*)?([A-Za-z ]+?)(?:\*\*)?:(?:\*\*)?\s*(.*)$`)
+	// planFileBulletRegex matches a file listed as a bullet: "- path/to/file - description"
+	planFileBulletRegex = regexp.MustCompile("^[-*+]\\s+`?([^`\\s]+)`?(?:\\s+[-—:]\\s+(.*))?$")
+)
+
+// PlanTask is a single numbered step from the Implementation Tasks section
+type PlanTask struct {
+	Number  int
+	Title   string
+	Files   []string
+	Changes string
+	Details []string // other sub-bullets, verbatim
+}
+
+// PlanFile is a row of the Files Modified table
+type PlanFile struct {
+	Path    string
+	Changes string
+}
+
+// PlanStructure is a plan.md split into its sections, with the task list and
+// file table parsed out
+type PlanStructure struct {
+	// Sections maps each "## " heading to its content; Order keeps the headings
+	// in document order
+	Sections map[string]string
+	Order    []string
+
+	Summary []string
+	Tasks   []PlanTask
+	Files   []PlanFile
+}
+
+// Section returns the content of the first section whose heading matches one of
+// names, case-insensitively. Exact matches win; otherwise a heading containing the
+// name matches, so renamed sections like "Tasks" or "Files Changed" still resolve.
+func (p *PlanStructure) Section(names ...string) string {
+	if heading := p.findSection(names...); heading != "" {
+		return p.Sections[heading]
+	}
+	return ""
+}
+
+func (p *PlanStructure) findSection(names ...string) string {
+	for _, name := range names {
+		for _, heading := range p.Order {
+			if strings.EqualFold(heading, name) {
+				return heading
+			}
+		}
+	}
+	for _, name := range names {
+		name = strings.ToLower(name)
+		for _, heading := range p.Order {
+			if strings.Contains(strings.ToLower(heading), name) {
+				return heading
+			}
+		}
+	}
+	return ""
+}
+
+// FilePaths returns every file the plan mentions, from the Files Modified table
+// and the task list, without duplicates
+func (p *PlanStructure) FilePaths() []string {
+	seen := make(map[string]bool)
+	var paths []string
+	add := func(path string) {
+		if path != "" && !seen[path] {
+			seen[path] = true
+			paths = append(paths, path)
+		}
+	}
+	for _, f := range p.Files {
+		add(f.Path)
+	}
+	for _, t := range p.Tasks {
+		for _, f := range t.Files {
+			add(f)
+		}
+	}
+	return paths
+}
+
+// MissingSections returns the "## " headings of original that revised no longer has,
+// compared case-insensitively. It works for any document split into "## " sections,
+// e.g. to check that a review kept an analysis's or plan's structure.
+func MissingSections(original, revised string) []string {
+	kept := make(map[string]bool)
+	for _, heading := range ParsePlan(revised).Order {
+		kept[strings.ToLower(heading)] = true
+	}
+	var missing []string
+	for _, heading := range ParsePlan(original).Order {
+		if !kept[strings.ToLower(heading)] {
+			missing = append(missing, heading)
+		}
+	}
+	return missing
+}
+
+// ParsePlan splits plan markdown into sections and parses the summary, task list
+// and file table. Missing sections leave the corresponding fields empty.
+func ParsePlan(content string) *PlanStructure {
+	plan := &PlanStructure{Sections: make(map[string]string)}
+
+	var heading string
+	var body []string
+	flush := func() {
+		if heading == "" {
+			return
+		}
+		text := strings.TrimSpace(strings.Join(body, "\n"))
+		// The "---" rule before Change Log belongs to neither section
+		text = strings.TrimSpace(strings.TrimSuffix(text, "---"))
+		if _, ok := plan.Sections[heading]; !ok {
+			plan.Order = append(plan.Order, heading)
+		}
+		plan.Sections[heading] = text
+	}
+
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+		}
+		if !inFence && strings.HasPrefix(line, "## ") {
+			flush()
+			heading = strings.TrimSpace(strings.TrimPrefix(line, "## "))
+			body = nil
+			continue
+		}
+		body = append(body, line)
+	}
+	flush()
+
+	plan.Summary = parseBullets(plan.Section("Plan Summary", "Summary"))
+	plan.Tasks = parsePlanTasks(plan.Section("Implementation Tasks", "Tasks", "Steps"))
+	plan.Files = parsePlanFiles(plan.Section("Files Modified", "Files"))
+	return plan
+}
+
+// parseBullets returns the text of each top-level bullet
+func parseBullets(section string) []string {
+	var bullets []string
+	for _, line := range strings.Split(section, "\n") {
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') {
+			continue
+		}
+		trimmed := strings.TrimSpace(line)
+		for _, prefix := range []string{"- ", "* ", "+ "} {
+			if strings.HasPrefix(trimmed, prefix) {
+				bullets = append(bullets, strings.TrimSpace(trimmed[len(prefix):]))
+				break
+			}
+		}
+	}
+	return bullets
+}
+
+// parsePlanTasks reads numbered tasks (as list items or ### headings) and their
+// File/Changes sub-bullets
+func parsePlanTasks(section string) []PlanTask {
+	var tasks []PlanTask
+	var current *PlanTask
+
+	for _, line := range strings.Split(section, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+
+		topLevel := line[0] != ' ' && line[0] != '\t'
+		var m []string
+		if topLevel {
+			if m = planTaskHeadingRegex.FindStringSubmatch(trimmed); m == nil {
+				m = planTaskRegex.FindStringSubmatch(trimmed)
+			}
+		}
+		if m != nil {
+			number, _ := strconv.Atoi(m[1])
+			tasks = append(tasks, PlanTask{Number: number, Title: stripEmphasis(m[2])})
+			current = &tasks[len(tasks)-1]
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		if f := planFieldRegex.FindStringSubmatch(trimmed); f != nil {
+			switch strings.ToLower(strings.TrimSpace(f[1])) {
+			case "file", "files":
+				current.Files = append(current.Files, splitFileList(f[2])...)
+				continue
+			case "changes", "change":
+				current.Changes = f[2]
+				continue
+			}
+		}
+		current.Details = append(current.Details, strings.TrimLeft(trimmed, "-*+ "))
+	}
+	return tasks
+}
+
+// parsePlanFiles reads the Files Modified table, falling back to a bullet list
+// when the section isn't a table
+func parsePlanFiles(section string) []PlanFile {
+	var files []PlanFile
+	header := true
+	for _, line := range strings.Split(section, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if !strings.HasPrefix(trimmed, "|") {
+			continue
+		}
+		cells := strings.Split(strings.Trim(trimmed, "|"), "|")
+		if header {
+			header = false
+			continue
+		}
+		if isTableSeparator(cells) {
+			continue
+		}
+		file := PlanFile{Path: stripCode(cells[0])}
+		if len(cells) > 1 {
+			file.Changes = strings.TrimSpace(cells[1])
+		}
+		if file.Path != "" {
+			files = append(files, file)
+		}
+	}
+	if len(files) > 0 {
+		return files
+	}
+
+	for _, line := range strings.Split(section, "\n") {
+		if m := planFileBulletRegex.FindStringSubmatch(strings.TrimSpace(line)); m != nil {
+			files = append(files, PlanFile{Path: stripCode(m[1]), Changes: strings.TrimSpace(m[2])})
+		}
+	}
+	return files
+}
+
+func isTableSeparator(cells []string) bool {
+	for _, cell := range cells {
+		if strings.Trim(strings.TrimSpace(cell), ":-") != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// splitFileList splits "a.go, `b.go`" into paths, dropping trailing notes like "(new)"
+func splitFileList(s string) []string {
+	var paths []string
+	for _, part := range strings.Split(s, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if path := stripCode(fields[0]); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths
+}
+
+func stripCode(s string) string {
+	return strings.Trim(strings.TrimSpace(s), "`*")
+}
+
+func stripEmphasis(s string) string {
+	return strings.TrimSpace(strings.Trim(strings.TrimSpace(s), "*"))
+}
@@ -0,0 +1,54 @@
+package model
+
+import "fmt"
+
+// Usage counts the tokens and cost of Claude calls. Fields the CLI doesn't report
+// stay zero.
+type Usage struct {
+	Calls                    int     `json:"calls,omitempty"`
+	InputTokens              int     `json:"input_tokens"`
+	OutputTokens             int     `json:"output_tokens"`
+	CacheCreationInputTokens int     `json:"cache_creation_input_tokens,omitempty"`
+	CacheReadInputTokens     int     `json:"cache_read_input_tokens,omitempty"`
+	CostUSD                  float64 `json:"cost_usd,omitempty"`
+}
+
+// Add accumulates other into u
+func (u *Usage) Add(other Usage) {
+	u.Calls += other.Calls
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+	u.CacheCreationInputTokens += other.CacheCreationInputTokens
+	u.CacheReadInputTokens += other.CacheReadInputTokens
+	u.CostUSD += other.CostUSD
+}
+
+// IsZero reports whether no tokens or cost were recorded
+func (u Usage) IsZero() bool {
+	return u.TotalInputTokens() == 0 && u.OutputTokens == 0 && u.CostUSD == 0
+}
+
+// TotalInputTokens counts input tokens including cache writes and reads
+func (u Usage) TotalInputTokens() int {
+	return u.InputTokens + u.CacheCreationInputTokens + u.CacheReadInputTokens
+}
+
+// String summarizes usage, e.g. "12.3k in / 4.5k out · $0.12"
+func (u Usage) String() string {
+	s := fmt.Sprintf("%s in / %s out", FormatTokens(u.TotalInputTokens()), FormatTokens(u.OutputTokens))
+	if u.CostUSD > 0 {
+		s += fmt.Sprintf(" · $%.2f", u.CostUSD)
+	}
+	return s
+}
+
+// FormatTokens abbreviates a token count: 950, 12.3k, 1.2M
+func FormatTokens(n int) string {
+	switch {
+	case n >= 1_000_000:
+		return fmt.Sprintf("%.1fM", float64(n)/1_000_000)
+	case n >= 1000:
+		return fmt.Sprintf("%.1fk", float64(n)/1000)
+	}
+	return fmt.Sprint(n)
+}
@@ -0,0 +1,167 @@
+// Package notify runs the shell commands and webhooks configured under hooks:
+// when an issue changes status.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/config"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+// hookTimeout bounds a single command or request so a hung hook can't pile up
+const hookTimeout = 30 * time.Second
+
+// Event is the payload sent to hooks, as JSON on stdin or as the POST body
+type Event struct {
+	Event   string `json:"event"`
+	IssueID string `json:"issue_id"`
+	Title   string `json:"title"`
+	Type    string `json:"type"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Project string `json:"project"`
+}
+
+// Notifier dispatches status changes to the configured hooks. Failures are logged
+// to hooks.log in the config directory and never surface to the caller.
+type Notifier struct {
+	storage *storage.Storage
+	hooks   []config.HookConfig
+	client  *http.Client
+	logger  *log.Logger
+	wg      sync.WaitGroup
+}
+
+// Attach installs a notifier as the storage's StatusChanged hook. Returns nil when
+// no hooks are configured.
+func Attach(s *storage.Storage, hooks []config.HookConfig) *Notifier {
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	n := &Notifier{
+		storage: s,
+		hooks:   hooks,
+		client:  &http.Client{Timeout: hookTimeout},
+		logger:  newLogger(),
+	}
+	s.Hooks.StatusChanged = n.statusChanged
+	return n
+}
+
+// Wait blocks until in-flight hooks finish. Safe to call on a nil Notifier.
+func (n *Notifier) Wait() {
+	if n == nil {
+		return
+	}
+	n.wg.Wait()
+}
+
+func (n *Notifier) statusChanged(issueID string, from, to model.IssueStatus) {
+	event := Event{
+		Event:   "status_changed",
+		IssueID: issueID,
+		From:    string(from),
+		To:      string(to),
+		Project: n.storage.ProjectRoot,
+	}
+	if issue, err := n.storage.LoadBrief(issueID); err == nil && issue != nil {
+		event.Title = issue.Title
+		event.Type = string(issue.Type)
+	}
+
+	for _, hook := range n.hooks {
+		if len(hook.Statuses) > 0 && !slices.Contains(hook.Statuses, event.To) {
+			continue
+		}
+		n.wg.Add(1)
+		go func(hook config.HookConfig) {
+			defer n.wg.Done()
+			var err error
+			if hook.Command != "" {
+				err = n.runCommand(hook.Command, event)
+			} else {
+				err = n.post(hook.URL, event)
+			}
+			if err != nil {
+				n.logger.Printf("hook for #%s (%s → %s): %v", event.IssueID, event.From, event.To, err)
+			}
+		}(hook)
+	}
+}
+
+// runCommand runs command with sh -c, passing the event as env vars and stdin JSON
+func (n *Notifier) runCommand(command string, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), hookTimeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	cmd.Dir = n.storage.ProjectRoot
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(),
+		"LFIM_EVENT="+event.Event,
+		"LFIM_ISSUE_ID="+event.IssueID,
+		"LFIM_ISSUE_TITLE="+event.Title,
+		"LFIM_ISSUE_TYPE="+event.Type,
+		"LFIM_STATUS_FROM="+event.From,
+		"LFIM_STATUS_TO="+event.To,
+		"LFIM_PROJECT_ROOT="+event.Project,
+	)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("%q: %w: %s", command, err, bytes.TrimSpace(output))
+	}
+	return nil
+}
+
+// post sends the event as JSON to url
+func (n *Notifier) post(url string, event Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	resp, err := n.client.Post(url, "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("POST %s: %s", url, resp.Status)
+	}
+	return nil
+}
+
+// newLogger appends to hooks.log in the config directory, discarding output if it
+// can't be opened: stderr would corrupt the TUI
+func newLogger() *log.Logger {
+	var w io.Writer = io.Discard
+	if dir := config.Dir(); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err == nil {
+			if f, err := os.OpenFile(filepath.Join(dir, "hooks.log"), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); err == nil {
+				w = f
+			}
+		}
+	}
+	return log.New(w, "", log.LstdFlags)
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// IssueWithArtifacts aggregates an issue with everything stored in its directory
+type IssueWithArtifacts struct {
+	Issue        *model.Issue
+	Analysis     string          // analysis.md content ("" if missing)
+	AnalysisJSON *model.Analysis // parsed analysis.json (nil if missing)
+	Plan         string          // plan.md content ("" if missing)
+	HasSession   bool            // true if a Claude session ID is stored
+
+	// Modification times (zero if the file doesn't exist)
+	BriefModified    time.Time
+	AnalysisModified time.Time
+	PlanModified     time.Time
+}
+
+// HasAnalysis reports whether any form of analysis (markdown or JSON) exists
+func (a *IssueWithArtifacts) HasAnalysis() bool {
+	return a.Analysis != "" || a.AnalysisJSON != nil
+}
+
+// HasPlan reports whether a plan exists
+func (a *IssueWithArtifacts) HasPlan() bool {
+	return a.Plan != ""
+}
+
+// GetIssueWithArtifacts loads an issue's brief together with its analysis, plan,
+// session presence and artifact timestamps in a single call
+func (s *Storage) GetIssueWithArtifacts(issueID string) (*IssueWithArtifacts, error) {
+	issue, err := s.LoadBrief(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if issue == nil {
+		return nil, fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	result := &IssueWithArtifacts{Issue: issue}
+
+	if result.Analysis, err = s.LoadAnalysis(issueID); err != nil {
+		return nil, fmt.Errorf("reading analysis: %w", err)
+	}
+	if result.AnalysisJSON, err = s.LoadAnalysisJSON(issueID); err != nil {
+		return nil, err
+	}
+	if result.Plan, err = s.LoadPlan(issueID); err != nil {
+		return nil, fmt.Errorf("reading plan: %w", err)
+	}
+
+	sessionID, _ := s.LoadSessionID(issueID)
+	result.HasSession = sessionID != ""
+
+	result.BriefModified = modTime(s.BriefPath(issueID))
+	result.AnalysisModified = modTime(s.AnalysisPath(issueID))
+	if jsonModified := modTime(s.AnalysisJSONPath(issueID)); jsonModified.After(result.AnalysisModified) {
+		result.AnalysisModified = jsonModified
+	}
+	result.PlanModified = modTime(s.PlanPath(issueID))
+
+	return result, nil
+}
+
+// modTime returns a file's modification time, or the zero time if it can't be read
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
@@ -0,0 +1,94 @@
+package storage
+
+import (
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+func TestGetIssueWithArtifactsBriefOnly(t *testing.T) {
+	s := newProject(t)
+	issue, err := s.CreateIssue("Brief only", model.TypeFeature, model.PriorityMedium, "Just a brief.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := s.GetIssueWithArtifacts(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Issue.ID != issue.ID || a.Issue.Title != "Brief only" || a.Issue.Content != "Just a brief." {
+		t.Errorf("issue = %+v", a.Issue)
+	}
+	if a.HasAnalysis() || a.HasPlan() || a.HasSession {
+		t.Errorf("brief-only issue reports artifacts: analysis %v, plan %v, session %v", a.HasAnalysis(), a.HasPlan(), a.HasSession)
+	}
+	if a.BriefModified.IsZero() {
+		t.Error("brief has no modification time")
+	}
+	if !a.AnalysisModified.IsZero() || !a.PlanModified.IsZero() {
+		t.Errorf("missing artifacts have modification times %s, %s", a.AnalysisModified, a.PlanModified)
+	}
+}
+
+func TestGetIssueWithArtifactsFull(t *testing.T) {
+	s := newProject(t)
+	issue, err := s.CreateIssue("Everything", model.TypeBug, model.PriorityHigh, "Body.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SaveAnalysisResult(issue.ID, "## Summary\nmarkdown", "sess-1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SavePlan(issue.ID, "## Plan Summary\n- step\n"); err != nil {
+		t.Fatal(err)
+	}
+	structured := &model.Analysis{
+		Summary: "structured",
+		Options: []model.AnalysisOption{{ID: "A", Title: "Fix it", Recommended: true}},
+	}
+	if err := s.SaveAnalysisJSON(issue.ID, structured); err != nil {
+		t.Fatal(err)
+	}
+	// analysis.md is rendered from the JSON; when the JSON is newer, its time wins
+	later := time.Now().Add(time.Hour)
+	if err := os.Chtimes(s.AnalysisJSONPath(issue.ID), later, later); err != nil {
+		t.Fatal(err)
+	}
+
+	a, err := s.GetIssueWithArtifacts(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(a.Analysis, "structured") || !strings.Contains(a.Analysis, "Fix it") {
+		t.Errorf("analysis.md = %q, want the rendered JSON analysis", a.Analysis)
+	}
+	if a.AnalysisJSON == nil || a.AnalysisJSON.Summary != "structured" || len(a.AnalysisJSON.Options) != 1 {
+		t.Errorf("analysis JSON = %+v", a.AnalysisJSON)
+	}
+	if a.Plan != "## Plan Summary\n- step\n" {
+		t.Errorf("plan = %q", a.Plan)
+	}
+	if !a.HasAnalysis() || !a.HasPlan() || !a.HasSession {
+		t.Errorf("artifacts missing: analysis %v, plan %v, session %v", a.HasAnalysis(), a.HasPlan(), a.HasSession)
+	}
+	if a.Issue.Status != model.StatusAnalyzed {
+		t.Errorf("status = %s, want analyzed", a.Issue.Status)
+	}
+	if !a.AnalysisModified.Equal(later) {
+		t.Errorf("analysis modified %s, want the JSON's %s", a.AnalysisModified, later)
+	}
+	if a.PlanModified.IsZero() {
+		t.Error("plan has no modification time")
+	}
+}
+
+func TestGetIssueWithArtifactsMissing(t *testing.T) {
+	s := newProject(t)
+	if a, err := s.GetIssueWithArtifacts("0042"); err == nil {
+		t.Errorf("missing issue loaded: %+v", a)
+	}
+}
@@ -0,0 +1,72 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// assertNoTempFiles fails if a write left its temporary file behind in dir
+func assertNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	matches, err := filepath.Glob(filepath.Join(dir, ".*.tmp-*"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(matches) != 0 {
+		t.Errorf("temporary files left behind: %q", matches)
+	}
+}
+
+func TestWriteFileAtomicReplaces(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.yaml")
+	writeFile(t, path, "old\n")
+
+	if err := writeFileAtomic(path, []byte("new\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	assertContent(t, path, "new\n")
+	if info, err := os.Stat(path); err != nil {
+		t.Fatal(err)
+	} else if runtime.GOOS != "windows" && info.Mode().Perm() != 0600 {
+		t.Errorf("mode = %v, want 0600", info.Mode().Perm())
+	}
+	assertNoTempFiles(t, dir)
+}
+
+// TestWriteFileAtomicFailureKeepsOld checks that a write failing before the rename
+// leaves the existing file as it was
+func TestWriteFileAtomicFailureKeepsOld(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("needs directory permissions to be enforced")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, "index.yaml")
+	writeFile(t, path, "old\n")
+	if err := os.Chmod(dir, 0555); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chmod(dir, 0755) })
+
+	if err := writeFileAtomic(path, []byte("new\n"), 0644); err == nil {
+		t.Fatal("write into a read-only directory succeeded")
+	}
+	assertContent(t, path, "old\n")
+	assertNoTempFiles(t, dir)
+}
+
+// TestWriteFileAtomicRenameFailure checks that a failed rename removes the
+// temporary file and leaves what was at the path untouched
+func TestWriteFileAtomicRenameFailure(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "brief.md")
+	writeFile(t, filepath.Join(path, "keep.md"), "old\n")
+
+	if err := writeFileAtomic(path, []byte("new\n"), 0644); err == nil {
+		t.Fatal("write over a non-empty directory succeeded")
+	}
+	assertContent(t, filepath.Join(path, "keep.md"), "old\n")
+	assertNoTempFiles(t, dir)
+}
@@ -0,0 +1,120 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+)
+
+// BlameLine is a line of the current analysis with the version that introduced it.
+// Version 0 marks lines edited into analysis.md outside the versioned review flow.
+type BlameLine struct {
+	Version int
+	Text    string
+}
+
+// AnalysisBlame annotates the current analysis with the version that added each line
+type AnalysisBlame struct {
+	Lines []BlameLine
+	// Removed counts the lines each version dropped from its predecessor
+	Removed map[int]int
+}
+
+// BlameAnalysis diffs analysis_v1..vN sequentially and attributes every line of the
+// current analysis.md to the version that introduced it
+func (s *Storage) BlameAnalysis(issueID string) (*AnalysisBlame, error) {
+	versions, err := s.ListAnalysisVersions(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(versions) == 0 {
+		return nil, fmt.Errorf("no analysis versions for %s", issueID)
+	}
+
+	blame := &AnalysisBlame{Removed: make(map[int]int)}
+	var previous []string
+	for _, v := range versions {
+		content, err := s.LoadAnalysisVersion(issueID, v)
+		if err != nil {
+			return nil, err
+		}
+		previous = blame.apply(previous, splitLines(content), v)
+	}
+
+	// Manual edits since the last review show up as version 0
+	current, err := s.LoadAnalysis(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if current != "" {
+		blame.apply(previous, splitLines(current), 0)
+	}
+	return blame, nil
+}
+
+// apply advances the blame from the previous version's lines to next, attributing
+// unmatched lines to version. Returns next.
+func (b *AnalysisBlame) apply(previous, next []string, version int) []string {
+	matches := matchLines(previous, next)
+
+	lines := make([]BlameLine, len(next))
+	kept := 0
+	for i, text := range next {
+		if j := matches[i]; j >= 0 {
+			lines[i] = BlameLine{Version: b.Lines[j].Version, Text: text}
+			kept++
+		} else {
+			lines[i] = BlameLine{Version: version, Text: text}
+		}
+	}
+
+	if removed := len(previous) - kept; removed > 0 {
+		b.Removed[version] += removed
+	}
+	b.Lines = lines
+	return next
+}
+
+// matchLines returns, for each line of b, the index of the line in a it is kept from
+// (per the longest common subsequence), or -1 if it was added
+func matchLines(a, b []string) []int {
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	matches := make([]int, len(b))
+	for j := range matches {
+		matches[j] = -1
+	}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			matches[j] = i
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return matches
+}
+
+func splitLines(content string) []string {
+	content = strings.TrimRight(content, "\n")
+	if content == "" {
+		return nil
+	}
+	return strings.Split(content, "\n")
+}
@@ -0,0 +1,82 @@
+package storage
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// statEntry is a cached result of os.Stat for a single path
+type statEntry struct {
+	exists  bool
+	modTime time.Time
+}
+
+// statCache memoizes file existence and mtime so rendering and key guards
+// don't hit the filesystem on every frame. Entries are invalidated when
+// storage writes the file and cleared wholesale on refresh.
+type statCache struct {
+	mu      sync.Mutex
+	entries map[string]statEntry
+	stats   int // os.Stat calls made, for the benchmarks
+}
+
+func newStatCache() *statCache {
+	return &statCache{entries: make(map[string]statEntry)}
+}
+
+// stat returns the cached existence and mtime for path, stat-ing on a miss
+func (c *statCache) stat(path string) statEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[path]; ok {
+		return entry
+	}
+
+	var entry statEntry
+	c.stats++
+	if info, err := os.Stat(path); err == nil {
+		entry = statEntry{exists: true, modTime: info.ModTime()}
+	}
+	c.entries[path] = entry
+	return entry
+}
+
+// invalidate drops cached entries for the given paths
+func (c *statCache) invalidate(paths ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range paths {
+		delete(c.entries, p)
+	}
+}
+
+// clear drops every cached entry
+func (c *statCache) clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = make(map[string]statEntry)
+}
+
+// fileExists reports whether path exists, using the stat cache
+func (s *Storage) fileExists(path string) bool {
+	return s.cache.stat(path).exists
+}
+
+// InvalidateCache forgets all cached artifact existence checks.
+// Call after external changes (editor sessions, manual refresh).
+func (s *Storage) InvalidateCache() {
+	s.cache.clear()
+}
+
+// InvalidateIssue forgets cached existence checks for one issue's artifacts
+func (s *Storage) InvalidateIssue(issueID string) {
+	s.cache.invalidate(
+		s.AnalysisPath(issueID),
+		s.AnalysisJSONPath(issueID),
+		s.PlanPath(issueID),
+	)
+}
@@ -0,0 +1,117 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// benchmarkIssues is the size of the project the list benchmarks render
+const benchmarkIssues = 1000
+
+// newBenchmarkProject returns storage for a project with n indexed issues, every
+// other one analyzed and planned
+func newBenchmarkProject(b *testing.B, n int) *Storage {
+	b.Helper()
+	s := New(b.TempDir(), "")
+	idx := model.NewIssueIndex()
+	for i := 1; i <= n; i++ {
+		issue := &model.Issue{
+			ID:       fmt.Sprintf("%04d", i),
+			Title:    fmt.Sprintf("Issue %d", i),
+			Type:     model.TypeBug,
+			Status:   model.StatusOpen,
+			Priority: model.PriorityMedium,
+			Created:  time.Now(),
+		}
+		writeFile(b, s.BriefPath(issue.ID), "---\ntitle: x\n---\nbody\n")
+		if i%2 == 0 {
+			issue.Status = model.StatusPlanned
+			writeFile(b, s.AnalysisPath(issue.ID), "## Summary\n")
+			writeFile(b, s.PlanPath(issue.ID), "## Plan Summary\n")
+		}
+		idx.AddIssue(issue)
+	}
+	if err := s.SaveIndex(idx); err != nil {
+		b.Fatal(err)
+	}
+	return s
+}
+
+// checkArtifacts does what the list does for every issue on each frame: look up
+// which artifacts exist (see the TUI's artifactsMismatch)
+func checkArtifacts(s *Storage, idx *model.IssueIndex) {
+	for _, issue := range idx.Issues {
+		_ = s.HasAnalysis(issue.ID)
+		_ = s.PlanExists(issue.ID)
+	}
+}
+
+// BenchmarkListArtifacts compares the stat calls of one pass over a large list
+// with the cache cleared first, as on every refresh (and on every frame before the
+// cache existed), against a pass over the warm cache, as on the frames between
+// refreshes. Compare the stats/op metric.
+func BenchmarkListArtifacts(b *testing.B) {
+	s := newBenchmarkProject(b, benchmarkIssues)
+	idx, err := s.LoadIndex()
+	if err != nil {
+		b.Fatal(err)
+	}
+	if len(idx.Issues) != benchmarkIssues {
+		b.Fatalf("loaded %d issues, want %d", len(idx.Issues), benchmarkIssues)
+	}
+
+	b.Run("uncached", func(b *testing.B) {
+		s.cache.stats = 0
+		for b.Loop() {
+			s.InvalidateCache()
+			checkArtifacts(s, idx)
+		}
+		b.ReportMetric(float64(s.cache.stats)/float64(b.N), "stats/op")
+	})
+
+	b.Run("cached", func(b *testing.B) {
+		s.InvalidateCache()
+		checkArtifacts(s, idx)
+		s.cache.stats = 0
+		for b.Loop() {
+			checkArtifacts(s, idx)
+		}
+		b.ReportMetric(float64(s.cache.stats)/float64(b.N), "stats/op")
+	})
+}
+
+func TestStatCacheInvalidation(t *testing.T) {
+	s := newProject(t)
+	issue, err := s.CreateIssue("Cached", model.TypeBug, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.PlanExists(issue.ID) {
+		t.Fatal("plan exists before it was saved")
+	}
+	// Saving through storage invalidates the cached miss
+	if err := s.SavePlan(issue.ID, "## Plan Summary\n"); err != nil {
+		t.Fatal(err)
+	}
+	if !s.PlanExists(issue.ID) {
+		t.Error("saved plan not seen through the cache")
+	}
+
+	// External changes are only seen after an invalidation
+	if s.AnalysisExists(issue.ID) {
+		t.Fatal("analysis exists before it was written")
+	}
+	writeFile(t, filepath.Join(s.IssueDir(issue.ID), "analysis.md"), "## Summary\n")
+	if s.AnalysisExists(issue.ID) {
+		t.Error("external write seen without an invalidation: the miss wasn't cached")
+	}
+	s.InvalidateIssue(issue.ID)
+	if !s.AnalysisExists(issue.ID) {
+		t.Error("external analysis not seen after InvalidateIssue")
+	}
+}
@@ -0,0 +1,83 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// TasksPath returns the path of an issue's optional dedicated checklist file
+func (s *Storage) TasksPath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), "tasks.md")
+}
+
+// hasTasksFile reports whether the issue keeps its checklist in tasks.md
+func (s *Storage) hasTasksFile(issueID string) bool {
+	_, err := os.Stat(s.TasksPath(issueID))
+	return err == nil
+}
+
+// LoadChecklist parses the issue's checklist from tasks.md if present, otherwise
+// from the brief body. Line numbers refer to that document.
+func (s *Storage) LoadChecklist(issueID string) ([]model.ChecklistItem, error) {
+	content, err := s.checklistContent(issueID)
+	if err != nil {
+		return nil, err
+	}
+	return model.ParseChecklist(content), nil
+}
+
+// ToggleChecklistItem flips the checkbox on the given line of the checklist document
+// and writes it back
+func (s *Storage) ToggleChecklistItem(issueID string, line int) error {
+	if s.hasTasksFile(issueID) {
+		content, err := s.checklistContent(issueID)
+		if err != nil {
+			return err
+		}
+		updated, err := model.ToggleChecklistLine(content, line)
+		if err != nil {
+			return err
+		}
+		if err := writeFileAtomic(s.TasksPath(issueID), []byte(updated), 0644); err != nil {
+			return err
+		}
+		s.gitAdd(s.TasksPath(issueID))
+		return nil
+	}
+
+	issue, err := s.LoadBrief(issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue not found: %s", issueID)
+	}
+	if issue.Content, err = model.ToggleChecklistLine(issue.Content, line); err != nil {
+		return err
+	}
+	if err := s.SaveBrief(issue); err != nil {
+		return err
+	}
+	s.gitAdd(s.BriefPath(issueID))
+	return nil
+}
+
+// checklistContent returns the document the checklist lives in
+func (s *Storage) checklistContent(issueID string) (string, error) {
+	if s.hasTasksFile(issueID) {
+		data, err := os.ReadFile(s.TasksPath(issueID))
+		if err != nil {
+			return "", fmt.Errorf("reading tasks: %w", err)
+		}
+		return s.decodeText(s.TasksPath(issueID), data), nil
+	}
+
+	issue, err := s.LoadBrief(issueID)
+	if err != nil || issue == nil {
+		return "", err
+	}
+	return issue.Content, nil
+}
@@ -0,0 +1,41 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// ValidateDependencies checks that the issues an issue blocks or is blocked by exist
+// in the index and aren't the issue itself
+func (s *Storage) ValidateDependencies(issue *model.Issue) error {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return err
+	}
+	problems := dependencyProblems(issue, func(id string) bool { return idx.GetIssue(id) != nil })
+	if len(problems) > 0 {
+		return fmt.Errorf("%s: %s", issue.ID, strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+// dependencyProblems describes each blocks/blocked_by reference of issue to itself
+// or to an issue that doesn't exist
+func dependencyProblems(issue *model.Issue, exists func(id string) bool) []string {
+	var problems []string
+	check := func(field string, ids []string) {
+		for _, id := range ids {
+			switch {
+			case id == issue.ID:
+				problems = append(problems, fmt.Sprintf("%s references the issue itself", field))
+			case !exists(id):
+				problems = append(problems, fmt.Sprintf("%s references missing issue %s", field, id))
+			}
+		}
+	}
+	check("blocks", issue.Blocks)
+	check("blocked_by", issue.BlockedBy)
+	return problems
+}
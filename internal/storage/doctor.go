@@ -0,0 +1,420 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// Severity ranks a doctor finding
+type Severity int
+
+const (
+	SeverityWarning Severity = iota
+	SeverityError
+)
+
+func (s Severity) String() string {
+	if s == SeverityError {
+		return "error"
+	}
+	return "warning"
+}
+
+// repair identifies the safe automatic fix for a problem
+type repair int
+
+const (
+	repairNone repair = iota
+	repairRebuildIndex
+	repairSyncEntry
+	repairRemoveEntry
+	repairAddEntry
+	repairDefaultStatus
+	repairClearSession
+	repairDropDuplicate
+)
+
+// Problem is a single finding from Diagnose
+type Problem struct {
+	Severity Severity
+	IssueID  string // empty for index-wide problems
+	Message  string
+	repair   repair
+}
+
+// Fixable reports whether Repair can fix the problem
+func (p Problem) Fixable() bool {
+	return p.repair != repairNone
+}
+
+// AddsToIndex reports whether repairing the problem adds an unindexed issue
+// directory to index.yaml, which callers may want to confirm first
+func (p Problem) AddsToIndex() bool {
+	return p.repair == repairAddEntry
+}
+
+// InconsistencyKind says which side of an index/filesystem mismatch is missing
+type InconsistencyKind int
+
+const (
+	// MissingDirectory is an index.yaml entry without an issue directory
+	MissingDirectory InconsistencyKind = iota
+	// Unindexed is an issue directory without an index.yaml entry
+	Unindexed
+)
+
+// Inconsistency is a mismatch between index.yaml and the issue directories
+type Inconsistency struct {
+	IssueID string
+	Kind    InconsistencyKind
+}
+
+func (i Inconsistency) String() string {
+	if i.Kind == MissingDirectory {
+		return "index.yaml entry has no issue directory"
+	}
+	return "not in index.yaml"
+}
+
+// ValidateConsistency cross-checks index.yaml against the issue directories,
+// returning entries without a directory and directories without an entry, each
+// sorted by ID. Read-only.
+func (s *Storage) ValidateConsistency() ([]Inconsistency, error) {
+	ids, err := s.ListIssueIDs()
+	if err != nil {
+		return nil, err
+	}
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	onDisk := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		onDisk[id] = true
+	}
+
+	var found []Inconsistency
+	for _, entry := range idx.Issues {
+		if !onDisk[entry.ID] {
+			found = append(found, Inconsistency{IssueID: entry.ID, Kind: MissingDirectory})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].IssueID < found[j].IssueID })
+	for _, id := range ids {
+		if idx.GetIssue(id) == nil {
+			found = append(found, Inconsistency{IssueID: id, Kind: Unindexed})
+		}
+	}
+	return found, nil
+}
+
+// ListIssueIDs returns the issue directories under issues/, sorted
+func (s *Storage) ListIssueIDs() ([]string, error) {
+	entries, err := os.ReadDir(s.IssuesDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		if entry.IsDir() && isIssueID(entry.Name()) {
+			ids = append(ids, entry.Name())
+		}
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// isIssueID reports whether name looks like an issue ID (all digits)
+func isIssueID(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// Diagnose checks the issues directory for index/filesystem drift, malformed or
+// invalid briefs, broken references, stale analyses and orphaned sessions
+func (s *Storage) Diagnose() ([]Problem, error) {
+	ids, err := s.ListIssueIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []Problem
+	add := func(severity Severity, issueID string, r repair, format string, args ...any) {
+		problems = append(problems, Problem{
+			Severity: severity,
+			IssueID:  issueID,
+			Message:  fmt.Sprintf(format, args...),
+			repair:   r,
+		})
+	}
+
+	idx, duplicates, err := s.readIndex()
+	if err != nil {
+		add(SeverityError, "", repairRebuildIndex, "%v", err)
+		idx = nil
+	}
+	for _, id := range duplicates {
+		add(SeverityError, id, repairDropDuplicate, "listed more than once in index.yaml (only the first entry is used)")
+	}
+
+	onDisk := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		onDisk[id] = true
+	}
+
+	// Directories whose brief can't be read are reported once, not also as unindexed
+	broken := make(map[string]bool)
+	for _, id := range ids {
+		data, err := os.ReadFile(s.BriefPath(id))
+		if err != nil {
+			add(SeverityError, id, repairNone, "no readable brief.md")
+			broken[id] = true
+			continue
+		}
+		fm, _, err := ParseFrontmatter(s.decodeText(s.BriefPath(id), data))
+		if err != nil {
+			add(SeverityError, id, repairNone, "malformed frontmatter: %v", err)
+			broken[id] = true
+			continue
+		}
+		issue, _ := s.LoadBrief(id)
+		if issue == nil {
+			continue
+		}
+
+		if GetString(fm, "title") == "" {
+			add(SeverityError, id, repairNone, "missing title")
+		}
+		switch {
+		case issue.Status == "":
+			add(SeverityWarning, id, repairDefaultStatus, "missing status (defaults to open)")
+		case !issue.Status.Valid():
+			add(SeverityError, id, repairNone, "invalid status %q", issue.Status)
+		}
+		if !issue.Type.Valid() {
+			add(SeverityError, id, repairNone, "invalid type %q", issue.Type)
+		}
+		if ref := issue.DuplicateOf; ref != "" && !onDisk[ref] {
+			add(SeverityError, id, repairNone, "duplicate_of references missing issue %s", ref)
+		}
+		for _, problem := range dependencyProblems(issue, func(ref string) bool { return onDisk[ref] }) {
+			add(SeverityError, id, repairNone, "%s", problem)
+		}
+
+		if idx != nil {
+			if entry := idx.GetIssue(id); entry != nil && (entry.Title != issue.Title || entry.Type != issue.Type || (issue.Status != "" && entry.Status != issue.Status)) {
+				add(SeverityWarning, id, repairSyncEntry, "index.yaml out of sync with brief.md")
+			}
+		}
+
+		hasAnalysis := s.AnalysisExists(id) || s.AnalysisJSONExists(id)
+		if hasAnalysis && issue.Status.IsActive() {
+			analyzed := modTime(s.AnalysisPath(id))
+			if jsonModified := modTime(s.AnalysisJSONPath(id)); jsonModified.After(analyzed) {
+				analyzed = jsonModified
+			}
+			if modTime(s.BriefPath(id)).After(analyzed.Add(time.Second)) {
+				add(SeverityWarning, id, repairNone, "brief.md changed after the analysis was written; consider re-analyzing")
+			}
+		}
+
+		if _, err := os.Stat(s.SessionPath(id)); err == nil {
+			switch {
+			case issue.Status.IsClosed():
+				add(SeverityWarning, id, repairClearSession, "orphaned Claude session (issue is %s)", issue.Status)
+			case !hasAnalysis:
+				add(SeverityWarning, id, repairClearSession, "orphaned Claude session (no analysis)")
+			}
+		}
+	}
+
+	if idx != nil {
+		inconsistencies, err := s.ValidateConsistency()
+		if err != nil {
+			return nil, err
+		}
+		for _, inc := range inconsistencies {
+			switch {
+			case inc.Kind == MissingDirectory:
+				add(SeverityError, inc.IssueID, repairRemoveEntry, "%s", inc)
+			case !broken[inc.IssueID]:
+				add(SeverityWarning, inc.IssueID, repairAddEntry, "%s", inc)
+			}
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		return problems[i].Severity > problems[j].Severity
+	})
+	return problems, nil
+}
+
+// Repair applies the safe fixes for problems: defaulting missing statuses to open,
+// removing orphaned sessions, dropping duplicate index entries, and bringing
+// index.yaml in line with the briefs -
+// entry by entry, or by a full rebuild when it is unreadable. Only the given
+// problems are repaired, so a caller can leave out unindexed directories it
+// shouldn't add. Returns a description of each fix applied.
+func (s *Storage) Repair(problems []Problem) ([]string, error) {
+	var fixed []string
+	rebuild := false
+	var entryFixes []Problem
+
+	for _, p := range problems {
+		switch p.repair {
+		case repairDefaultStatus:
+			issue, err := s.LoadBrief(p.IssueID)
+			if err != nil || issue == nil {
+				continue
+			}
+			issue.Status = model.StatusOpen
+			if err := s.SaveBrief(issue); err != nil {
+				return fixed, err
+			}
+			s.gitAdd(s.BriefPath(p.IssueID))
+			fixed = append(fixed, fmt.Sprintf("%s: set status to open", p.IssueID))
+			entryFixes = append(entryFixes, Problem{IssueID: p.IssueID, repair: repairSyncEntry})
+		case repairClearSession:
+			if err := s.ClearSessionID(p.IssueID); err != nil {
+				return fixed, err
+			}
+			fixed = append(fixed, fmt.Sprintf("%s: removed orphaned session", p.IssueID))
+		case repairRebuildIndex:
+			rebuild = true
+		case repairSyncEntry, repairRemoveEntry, repairAddEntry, repairDropDuplicate:
+			entryFixes = append(entryFixes, p)
+		}
+	}
+
+	if !rebuild && len(entryFixes) > 0 {
+		applied, err := s.repairIndexEntries(entryFixes)
+		fixed = append(fixed, applied...)
+		if err != nil {
+			return fixed, err
+		}
+	}
+
+	if rebuild {
+		idx, err := s.RebuildIndex()
+		if err != nil {
+			return fixed, err
+		}
+		fixed = append(fixed, fmt.Sprintf("rebuilt index.yaml from %d briefs", len(idx.Issues)))
+	}
+	return fixed, nil
+}
+
+// repairIndexEntries syncs, removes and adds individual index.yaml entries, and
+// drops duplicate ones
+func (s *Storage) repairIndexEntries(problems []Problem) ([]string, error) {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	var fixed []string
+	for _, p := range problems {
+		switch p.repair {
+		case repairDropDuplicate:
+			// LoadIndex already kept only the first entry; saving drops the others
+			fixed = append(fixed, fmt.Sprintf("%s: removed duplicate index entries", p.IssueID))
+		case repairRemoveEntry:
+			if idx.RemoveIssue(p.IssueID) {
+				fixed = append(fixed, fmt.Sprintf("%s: removed dead index entry", p.IssueID))
+			}
+		case repairSyncEntry, repairAddEntry:
+			issue, err := s.LoadBrief(p.IssueID)
+			if err != nil || issue == nil {
+				continue
+			}
+			issue.Content = ""
+			if entry := idx.GetIssue(p.IssueID); entry != nil {
+				if !entry.Created.IsZero() {
+					issue.Created = entry.Created
+				}
+				idx.UpdateIssue(issue)
+				fixed = append(fixed, fmt.Sprintf("%s: synced index entry with brief.md", p.IssueID))
+			} else {
+				if issue.Created.IsZero() {
+					issue.Created = modTime(s.BriefPath(p.IssueID))
+				}
+				idx.AddIssue(issue)
+				fixed = append(fixed, fmt.Sprintf("%s: added to index.yaml", p.IssueID))
+			}
+		}
+	}
+	if len(fixed) == 0 {
+		return nil, nil
+	}
+
+	idx.SortByID()
+	if err := s.SaveIndex(idx); err != nil {
+		return nil, err
+	}
+	s.gitAdd(s.IndexPath())
+	return fixed, nil
+}
+
+// RebuildIndex regenerates index.yaml from the briefs on disk, keeping creation
+// dates from the old index where it is readable. IDs come from the directory names;
+// directories without a loadable brief.md are skipped. Returns the new index.
+func (s *Storage) RebuildIndex() (*model.IssueIndex, error) {
+	ids, err := s.ListIssueIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	old, err := s.LoadIndex()
+	if err != nil {
+		old = model.NewIssueIndex()
+	}
+
+	idx := model.NewIssueIndex()
+	for _, id := range ids {
+		issue, err := s.LoadBrief(id)
+		if err != nil || issue == nil {
+			continue
+		}
+		if entry := old.GetIssue(id); entry != nil && !entry.Created.IsZero() {
+			issue.Created = entry.Created
+		} else if issue.Created.IsZero() {
+			issue.Created = modTime(s.BriefPath(id))
+		}
+		if entry := old.GetIssue(id); entry != nil && entry.Updated.After(issue.Updated) {
+			issue.Updated = entry.Updated
+		}
+		issue.Content = ""
+		idx.AddIssue(issue)
+	}
+	idx.SortByID()
+
+	if err := s.SaveIndex(idx); err != nil {
+		return nil, err
+	}
+	s.gitAdd(s.IndexPath())
+	return idx, nil
+}
+
+// EnsureIndex loads index.yaml, rebuilding it from the briefs when it is missing
+// but issue directories exist, so a deleted index doesn't hide every issue
+func (s *Storage) EnsureIndex() (*model.IssueIndex, error) {
+	if _, err := os.Stat(s.IndexPath()); os.IsNotExist(err) {
+		if ids, _ := s.ListIssueIDs(); len(ids) > 0 {
+			return s.RebuildIndex()
+		}
+	}
+	return s.LoadIndex()
+}
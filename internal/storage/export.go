@@ -0,0 +1,207 @@
+package storage
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// ExportVersion identifies the export format; Import rejects other versions
+const ExportVersion = 1
+
+// Export is a self-contained copy of every indexed issue. Import of an Export
+// reproduces the issues exactly: each issue's index metadata (ID, title, type,
+// status, priority, labels, blocks, blocked_by, created, updated and closed dates,
+// discard reason, duplicate-of) plus the byte-for-byte content of every file in its
+// directory - brief, analysis (markdown and JSON), plan, their versions, feedback,
+// tasks and usage - except runtime files (see runtimeFiles).
+type Export struct {
+	Version  int             `json:"version"`
+	Exported time.Time       `json:"exported"`
+	Issues   []ExportedIssue `json:"issues"`
+}
+
+// ExportedIssue is one issue's index entry and directory contents
+type ExportedIssue struct {
+	*model.Issue
+	Files []ExportedFile `json:"files"`
+}
+
+// ExportedFile is a file from an issue directory. Content is the text itself, or
+// base64 when Encoding is "base64" (files that aren't valid UTF-8).
+type ExportedFile struct {
+	Path     string `json:"path"` // slash-separated, relative to the issue directory
+	Content  string `json:"content"`
+	Encoding string `json:"encoding,omitempty"`
+}
+
+// runtimeFiles only matter to the lfim that wrote them: the Claude session, streamed
+// partial output, a rejected review and the stderr log. They are not exported, nor
+// are temporary files.
+var runtimeFiles = map[string]bool{
+	".session":             true,
+	".analysis.partial.md": true,
+	".review.rejected.md":  true,
+	".claude.log":          true,
+}
+
+// Export writes every indexed issue and its files to w as indented JSON
+func (s *Storage) Export(w io.Writer) error {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return err
+	}
+
+	export := Export{Version: ExportVersion, Exported: time.Now(), Issues: []ExportedIssue{}}
+	for _, issue := range idx.Issues {
+		exported, err := s.exportIssue(issue)
+		if err != nil {
+			return err
+		}
+		export.Issues = append(export.Issues, *exported)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(export)
+}
+
+// ExportIssue returns one indexed issue in export form, or nil if the index
+// doesn't have it
+func (s *Storage) ExportIssue(issueID string) (*ExportedIssue, error) {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+	issue := idx.GetIssue(issueID)
+	if issue == nil {
+		return nil, nil
+	}
+	return s.exportIssue(issue)
+}
+
+func (s *Storage) exportIssue(issue *model.Issue) (*ExportedIssue, error) {
+	files, err := s.exportFiles(issue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("exporting %s: %w", issue.ID, err)
+	}
+	entry := *issue
+	entry.Content = "" // brief.md carries the body
+	return &ExportedIssue{Issue: &entry, Files: files}, nil
+}
+
+// exportFiles reads every file under an issue directory, skipping leftovers of
+// interrupted atomic writes
+func (s *Storage) exportFiles(issueID string) ([]ExportedFile, error) {
+	dir := s.IssueDir(issueID)
+	files := []ExportedFile{}
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == dir {
+			return fs.SkipAll // indexed but missing; doctor reports it
+		}
+		if err != nil || d.IsDir() || runtimeFiles[d.Name()] || strings.Contains(d.Name(), ".tmp-") {
+			return err
+		}
+
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		file := ExportedFile{Path: filepath.ToSlash(rel), Content: string(data)}
+		if !utf8.Valid(data) {
+			file.Content = base64.StdEncoding.EncodeToString(data)
+			file.Encoding = "base64"
+		}
+		files = append(files, file)
+		return nil
+	})
+	return files, err
+}
+
+// Import recreates the issues of an Export read from r. It refuses to overwrite:
+// every imported ID must be new to the index and have no directory yet.
+func (s *Storage) Import(r io.Reader) ([]*model.Issue, error) {
+	var export Export
+	if err := json.NewDecoder(r).Decode(&export); err != nil {
+		return nil, fmt.Errorf("parsing export: %w", err)
+	}
+	if export.Version != ExportVersion {
+		return nil, fmt.Errorf("unsupported export version %d (expected %d)", export.Version, ExportVersion)
+	}
+
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return nil, err
+	}
+
+	// Check everything before writing anything
+	seen := make(map[string]bool)
+	for _, exported := range export.Issues {
+		if exported.Issue == nil || exported.ID == "" {
+			return nil, errors.New("export contains an issue without an ID")
+		}
+		id := exported.ID
+		if !filepath.IsLocal(id) || strings.ContainsAny(id, `/\`) {
+			return nil, fmt.Errorf("invalid issue ID %q", id)
+		}
+		if seen[id] {
+			return nil, fmt.Errorf("issue %s appears twice in the export", id)
+		}
+		if idx.GetIssue(id) != nil {
+			return nil, fmt.Errorf("issue %s already exists", id)
+		}
+		if _, err := os.Stat(s.IssueDir(id)); err == nil {
+			return nil, fmt.Errorf("issue directory %s already exists", s.IssueDir(id))
+		}
+		for _, file := range exported.Files {
+			if !filepath.IsLocal(filepath.FromSlash(file.Path)) {
+				return nil, fmt.Errorf("issue %s: invalid file path %q", id, file.Path)
+			}
+		}
+		seen[id] = true
+	}
+
+	var imported []*model.Issue
+	var paths []string
+	for _, exported := range export.Issues {
+		for _, file := range exported.Files {
+			data := []byte(file.Content)
+			if file.Encoding == "base64" {
+				if data, err = base64.StdEncoding.DecodeString(file.Content); err != nil {
+					return nil, fmt.Errorf("issue %s: decoding %s: %w", exported.ID, file.Path, err)
+				}
+			}
+			path := filepath.Join(s.IssueDir(exported.ID), filepath.FromSlash(file.Path))
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return nil, fmt.Errorf("creating issue dir: %w", err)
+			}
+			if err := writeFileAtomic(path, data, 0644); err != nil {
+				return nil, fmt.Errorf("issue %s: writing %s: %w", exported.ID, file.Path, err)
+			}
+			paths = append(paths, path)
+		}
+
+		idx.AddIssue(exported.Issue)
+		imported = append(imported, exported.Issue)
+	}
+
+	if err := s.SaveIndex(idx); err != nil {
+		return nil, err
+	}
+	s.gitAdd(append(paths, s.IndexPath())...)
+	return imported, nil
+}
@@ -0,0 +1,214 @@
+package storage
+
+import (
+	"bytes"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// newProject returns storage for an empty project outside git
+func newProject(t *testing.T) *Storage {
+	t.Helper()
+	s := New(t.TempDir(), "")
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+// exportFixture fills s with issues covering every exported field and file kind:
+// analysis and plan versions, feedback, labels, a discarded duplicate and a file
+// that isn't valid UTF-8
+func exportFixture(t *testing.T, s *Storage) {
+	t.Helper()
+	first, err := s.CreateIssue("Login drops the session", model.TypeBug, model.PriorityHigh, "Redirects lose the cookie.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.CreateIssue("Same login bug", model.TypeFeature, model.PriorityLow, "Duplicate report.")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := s.SaveAnalysisResult(first.ID, "## Summary\nv1", "sess-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SaveAnalysisRevision(first.ID, "## Summary\nv2, after feedback"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AppendFeedback(first.ID, "Mention the redirect helper"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SavePlanRevision(first.ID, "## Plan Summary\n- fix it\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.UpdateIssueStatus(first.ID, model.StatusPlanned, ""); err != nil {
+		t.Fatal(err)
+	}
+	brief, err := s.LoadBrief(first.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	brief.Labels = []string{"auth", "regression"}
+	if err := s.SaveBrief(brief); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SyncBriefToIndex(first.ID); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(s.IssueDir(first.ID), "attachments", "trace.bin"), "\xff\xfe\x00trace")
+	writeFile(t, filepath.Join(s.IssueDir(first.ID), ".claude.log"), "warning\n")
+	writeFile(t, filepath.Join(s.IssueDir(first.ID), ".analysis.partial.md"), "## Summ")
+
+	if err := s.UpdateIssueStatus(second.ID, model.StatusInvalid, "Duplicate of "+first.ID); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readTree returns every file under dir by slash-separated relative path
+func readTree(t *testing.T, dir string) map[string][]byte {
+	t.Helper()
+	files := make(map[string][]byte)
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(dir, path)
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return files
+}
+
+// indexEntries returns the index as its serialized entries, which compare by value
+func indexEntries(t *testing.T, s *Storage) []map[string]interface{} {
+	t.Helper()
+	idx, err := s.LoadIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []map[string]interface{}
+	for _, issue := range idx.Issues {
+		entry := issue.ToIndexEntry()
+		if issue.DiscardReason != "" {
+			entry["discard_reason"] = issue.DiscardReason
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestExportImportRoundTrip(t *testing.T) {
+	src := newProject(t)
+	exportFixture(t, src)
+
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), `"encoding": "base64"`) {
+		t.Error("non-UTF-8 file was not exported as base64")
+	}
+
+	dst := newProject(t)
+	imported, err := dst.Import(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(imported) != 2 {
+		t.Fatalf("imported %d issues, want 2", len(imported))
+	}
+
+	if got, want := indexEntries(t, dst), indexEntries(t, src); !reflect.DeepEqual(got, want) {
+		t.Errorf("imported index differs:\ngot  %v\nwant %v", got, want)
+	}
+	for _, id := range []string{"0001", "0002"} {
+		got, want := readTree(t, dst.IssueDir(id)), readTree(t, src.IssueDir(id))
+		for name := range runtimeFiles {
+			delete(want, name)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("files of %s differ:\ngot  %q\nwant %q", id, got, want)
+		}
+	}
+
+	// Spot-check that the interesting files made it, not just that both sides agree
+	files := readTree(t, dst.IssueDir("0001"))
+	for _, name := range []string{"brief.md", "analysis.md", "analysis_v1.md", "analysis_v2.md", "feedback.md", "plan.md", "plan_v1.md", ".analysis_version", "attachments/trace.bin"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("imported 0001 is missing %s", name)
+		}
+	}
+	for _, name := range []string{".session", ".claude.log", ".analysis.partial.md"} {
+		if _, ok := files[name]; ok {
+			t.Errorf("runtime file %s was exported", name)
+		}
+	}
+	if got := string(files["attachments/trace.bin"]); got != "\xff\xfe\x00trace" {
+		t.Errorf("binary file = %q after the round trip", got)
+	}
+}
+
+func TestImportRefusesToOverwrite(t *testing.T) {
+	src := newProject(t)
+	exportFixture(t, src)
+	var buf bytes.Buffer
+	if err := src.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	export := buf.Bytes()
+
+	// Into the same project: every ID is already indexed
+	before := readTree(t, src.IssuesDir)
+	if _, err := src.Import(bytes.NewReader(export)); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("import over existing issues: err = %v, want already exists", err)
+	}
+	if after := readTree(t, src.IssuesDir); !reflect.DeepEqual(after, before) {
+		t.Error("refused import changed files")
+	}
+
+	// An unindexed directory in the way is refused too, before anything is written
+	dst := newProject(t)
+	writeFile(t, filepath.Join(dst.IssueDir("0002"), "brief.md"), "stray\n")
+	if _, err := dst.Import(bytes.NewReader(export)); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Fatalf("import over a stray directory: err = %v, want already exists", err)
+	}
+	if _, err := os.Stat(dst.IssueDir("0001")); !os.IsNotExist(err) {
+		t.Error("refused import wrote 0001")
+	}
+}
+
+func TestImportRejectsPathsOutsideTheIssue(t *testing.T) {
+	for name, export := range map[string]string{
+		"file path":     `{"version": 1, "issues": [{"id": "0001", "title": "x", "files": [{"path": "../../escape.md", "content": "x"}]}]}`,
+		"absolute path": `{"version": 1, "issues": [{"id": "0001", "title": "x", "files": [{"path": "/tmp/escape.md", "content": "x"}]}]}`,
+		"issue ID":      `{"version": 1, "issues": [{"id": "../escape", "title": "x", "files": []}]}`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			s := newProject(t)
+			_, err := s.Import(strings.NewReader(export))
+			if err == nil || !strings.Contains(err.Error(), "invalid") {
+				t.Fatalf("err = %v, want an invalid path or ID error", err)
+			}
+			if _, err := os.Stat(filepath.Join(s.ProjectRoot, "escape.md")); !os.IsNotExist(err) {
+				t.Error("import wrote outside the issues directory")
+			}
+			if entries := indexEntries(t, s); len(entries) != 0 {
+				t.Errorf("rejected import indexed %v", entries)
+			}
+		})
+	}
+}
@@ -4,14 +4,26 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"gopkg.in/yaml.v3"
 )
 
 var frontmatterRegex = regexp.MustCompile(`(?s)^---\s*\n(.*?)\n---\s*\n?(.*)`)
 
+// normalizeNewlines converts CRLF (and stray CR) line endings to LF so files written
+// on Windows or by CRLF editors parse and render like everything else
+func normalizeNewlines(content string) string {
+	if !strings.Contains(content, "\r") {
+		return content
+	}
+	content = strings.ReplaceAll(content, "\r\n", "\n")
+	return strings.ReplaceAll(content, "\r", "\n")
+}
+
 // ParseFrontmatter extracts YAML frontmatter and body from markdown content
 func ParseFrontmatter(content string) (map[string]interface{}, string, error) {
+	content = normalizeNewlines(content)
 	matches := frontmatterRegex.FindStringSubmatch(content)
 	if matches == nil {
 		return nil, strings.TrimSpace(content), nil
@@ -72,3 +84,59 @@ func GetString(m map[string]interface{}, key string) string {
 	}
 	return ""
 }
+
+// GetTime returns a timestamp value from a frontmatter/index map: RFC3339, or a
+// plain date as older versions wrote. YAML decodes unquoted timestamps into a
+// time.Time itself. Returns the zero time if the value is missing or unparsable.
+func GetTime(m map[string]interface{}, key string) time.Time {
+	switch val := m[key].(type) {
+	case time.Time:
+		return val
+	case string:
+		if t, err := time.Parse(time.RFC3339, val); err == nil {
+			return t
+		}
+		if t, err := time.Parse(time.DateOnly, val); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+// GetStringSlice returns a list value from a frontmatter/index map. A scalar string
+// (as written by hand in older files) becomes a single-element slice.
+func GetStringSlice(m map[string]interface{}, key string) []string {
+	var values []string
+	switch val := m[key].(type) {
+	case []interface{}:
+		for _, item := range val {
+			if s := strings.TrimSpace(fmt.Sprint(item)); s != "" {
+				values = append(values, s)
+			}
+		}
+	case string:
+		if s := strings.TrimSpace(val); s != "" {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// GetIDSlice returns a list of issue IDs from a frontmatter/index map. Unquoted
+// IDs decode as integers, so they are zero-padded back like GetString does for "id".
+func GetIDSlice(m map[string]interface{}, key string) []string {
+	var ids []string
+	switch val := m[key].(type) {
+	case []interface{}:
+		for _, item := range val {
+			if id := GetString(map[string]interface{}{"id": item}, "id"); id != "" {
+				ids = append(ids, id)
+			}
+		}
+	default:
+		if id := GetString(map[string]interface{}{"id": val}, "id"); id != "" {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
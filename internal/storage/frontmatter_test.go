@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// copyFixture copies testdata/<name> to path byte for byte
+func copyFixture(t *testing.T, name, path string) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, path, string(data))
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"a\nb\n", "a\nb\n"},
+		{"a\r\nb\r\n", "a\nb\n"},
+		{"a\rb\r", "a\nb\n"},
+		{"a\r\n\r\nb\rc\n", "a\n\nb\nc\n"},
+		{"", ""},
+	}
+	for _, tt := range tests {
+		if got := normalizeNewlines(tt.in); got != tt.want {
+			t.Errorf("normalizeNewlines(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoadBriefCRLF(t *testing.T) {
+	s := newProject(t)
+	copyFixture(t, "crlf/brief.md", s.BriefPath("0001"))
+
+	issue, err := s.LoadBrief("0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue == nil {
+		t.Fatal("CRLF brief not loaded")
+	}
+	if issue.Title != "Export drops CRLF files" || issue.Type != model.TypeBug || issue.Status != model.StatusOpen || issue.Priority != model.PriorityHigh {
+		t.Errorf("frontmatter = %q %s %s %s", issue.Title, issue.Type, issue.Status, issue.Priority)
+	}
+	if !reflect.DeepEqual(issue.Labels, []string{"windows"}) {
+		t.Errorf("labels = %q, want [windows]", issue.Labels)
+	}
+	want := "Briefs saved by a Windows editor end lines with CRLF.\n\n- [ ] reproduce\n- [ ] fix"
+	if issue.Content != want {
+		t.Errorf("body = %q, want %q", issue.Content, want)
+	}
+	if items := model.ParseChecklist(issue.Content); len(items) != 2 {
+		t.Errorf("checklist has %d items, want 2", len(items))
+	}
+}
+
+func TestLoadPlanCRLF(t *testing.T) {
+	s := newProject(t)
+	copyFixture(t, "crlf/plan.md", s.PlanPath("0001"))
+
+	content, err := s.LoadPlan("0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(content, "\r") {
+		t.Errorf("plan still has carriage returns: %q", content)
+	}
+
+	plan, err := s.LoadPlanSections("0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Plan Summary", "Implementation Tasks", "Files Modified", "Testing Approach"}; !reflect.DeepEqual(plan.Order, want) {
+		t.Errorf("sections = %q, want %q", plan.Order, want)
+	}
+	if want := []string{"Normalize line endings when reading", "Keep files as written"}; !reflect.DeepEqual(plan.Summary, want) {
+		t.Errorf("summary = %q, want %q", plan.Summary, want)
+	}
+	if want := []model.PlanFile{{Path: "internal/storage/text.go", Changes: "Normalize CRLF"}}; !reflect.DeepEqual(plan.Files, want) {
+		t.Errorf("files = %+v, want %+v", plan.Files, want)
+	}
+}
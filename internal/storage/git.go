@@ -1,20 +1,116 @@
 package storage
 
 import (
+	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 )
 
-// StageIssueFiles stages all issue files (brief, analysis, plan, index) for git commit.
-// Called before implement to stage confirmed files.
-func (s *Storage) StageIssueFiles(issueID string) {
-	s.gitAdd(
+// IssueFilePaths returns the tracked files that belong to an issue, plus the index
+func (s *Storage) IssueFilePaths(issueID string) []string {
+	return []string{
 		s.BriefPath(issueID),
 		s.AnalysisPath(issueID),
+		s.AnalysisJSONPath(issueID),
 		s.PlanPath(issueID),
+		s.FeedbackPath(issueID),
+		s.TasksPath(issueID),
 		s.IndexPath(),
+	}
+}
+
+// IssueArtifactPaths returns the issue's files that exist and belong in git:
+// IssueFilePaths plus the analysis and plan versions, their trackers and the usage
+// totals. Runtime files (.session, partial output, rejected reviews, .claude.log,
+// temporary files) are left out. Both close modes commit exactly these.
+func (s *Storage) IssueArtifactPaths(issueID string) []string {
+	paths := append(s.IssueFilePaths(issueID),
+		s.VersionTrackerPath(issueID),
+		s.PlanVersionTrackerPath(issueID),
+		s.UsagePath(issueID),
 	)
+	if versions, err := s.ListAnalysisVersions(issueID); err == nil {
+		for _, v := range versions {
+			paths = append(paths, s.AnalysisVersionPath(issueID, v))
+		}
+	}
+	if versions, err := s.ListPlanVersions(issueID); err == nil {
+		for _, v := range versions {
+			paths = append(paths, s.PlanVersionPath(issueID, v))
+		}
+	}
+
+	var existing []string
+	for _, p := range paths {
+		if _, err := os.Stat(p); err == nil {
+			existing = append(existing, p)
+		}
+	}
+	return existing
+}
+
+// StageIssueFiles stages the issue's artifacts (see IssueArtifactPaths) for git commit.
+// Called before implement so the confirmed files form the baseline and the diff Claude
+// produces contains only code changes. No-op outside a git repository.
+func (s *Storage) StageIssueFiles(issueID string) {
+	if !s.IsGitRepo() {
+		return
+	}
+	s.gitAdd(s.IssueArtifactPaths(issueID)...)
+}
+
+// CommitIssueFiles stages and commits only the issue's artifacts, and removals
+// already staged under its directory (e.g. pruned versions), leaving any other
+// staged changes in the index untouched
+func (s *Storage) CommitIssueFiles(issueID, message string) (bool, string) {
+	paths := s.IssueArtifactPaths(issueID)
+	add := exec.Command("git", append([]string{"add", "--"}, paths...)...)
+	add.Dir = s.ProjectRoot
+	if output, err := add.CombinedOutput(); err != nil {
+		return false, string(output)
+	}
+
+	paths = append(paths, s.stagedRemovals(s.IssueDir(issueID))...)
+	args := append([]string{"commit", "-m", message, "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.ProjectRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, string(output)
+	}
+	return true, string(output)
+}
+
+// stagedRemovals returns the files under dir whose deletion is staged
+func (s *Storage) stagedRemovals(dir string) []string {
+	cmd := exec.Command("git", "diff", "--cached", "--no-renames", "--relative", "--name-only", "--diff-filter=D", "--", dir)
+	cmd.Dir = s.ProjectRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return nil
+	}
+	var paths []string
+	for _, line := range strings.Split(strings.TrimSpace(string(output)), "\n") {
+		if line != "" {
+			paths = append(paths, filepath.Join(s.ProjectRoot, line))
+		}
+	}
+	return paths
+}
+
+// CommitPaths commits only the given paths, leaving any other staged changes in
+// the index untouched
+func (s *Storage) CommitPaths(message string, paths []string) (bool, string) {
+	args := append([]string{"commit", "-m", message, "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.ProjectRoot
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return false, string(output)
+	}
+	return true, string(output)
 }
 
 // gitAdd stages files to git. Silently fails if not a git repo.
@@ -36,6 +132,15 @@ func (s *Storage) gitAdd(paths ...string) {
 	_ = cmd.Run() // Ignore errors
 }
 
+// gitRemove stages the removal of paths that were deleted from the working tree.
+// Silently fails if not a git repo or the paths were never tracked.
+func (s *Storage) gitRemove(paths ...string) {
+	args := append([]string{"rm", "-r", "--cached", "--quiet", "--ignore-unmatch", "--"}, paths...)
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.ProjectRoot
+	_ = cmd.Run() // Ignore errors
+}
+
 // HasStagedChanges checks if there are staged changes to commit
 func (s *Storage) HasStagedChanges() bool {
 	cmd := exec.Command("git", "diff", "--cached", "--stat")
@@ -65,6 +170,24 @@ func (s *Storage) IsGitRepo() bool {
 	return cmd.Run() == nil
 }
 
+// ProjectName returns the git repository name, or the project root's basename
+// outside a git repository
+func (s *Storage) ProjectName() string {
+	cmd := exec.Command("git", "rev-parse", "--show-toplevel")
+	cmd.Dir = s.ProjectRoot
+	if output, err := cmd.Output(); err == nil {
+		if top := strings.TrimSpace(string(output)); top != "" {
+			return filepath.Base(top)
+		}
+	}
+
+	root, err := filepath.Abs(s.ProjectRoot)
+	if err != nil {
+		root = s.ProjectRoot
+	}
+	return filepath.Base(root)
+}
+
 // GitStatus returns the current git status
 func (s *Storage) GitStatus() string {
 	cmd := exec.Command("git", "status", "--short")
@@ -76,6 +199,30 @@ func (s *Storage) GitStatus() string {
 	return string(output)
 }
 
+// GitDiffStat returns the diffstat of unstaged working tree changes
+func (s *Storage) GitDiffStat() string {
+	cmd := exec.Command("git", "diff", "--stat")
+	cmd.Dir = s.ProjectRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
+// IssueFileDiff returns the diff of one of an issue's files (e.g. "plan.md") in the
+// working tree against HEAD, staged changes included. Empty when the file matches
+// HEAD, and outside a git repository or one without commits.
+func (s *Storage) IssueFileDiff(issueID, file string) string {
+	cmd := exec.Command("git", "diff", "HEAD", "--", filepath.Join(s.IssueDir(issueID), file))
+	cmd.Dir = s.ProjectRoot
+	output, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return string(output)
+}
+
 // GetGitDiff returns the git diff for the current branch compared to HEAD~1
 // This captures changes made during implementation
 func (s *Storage) GetGitDiff() string {
@@ -104,3 +251,58 @@ func (s *Storage) GetGitDiff() string {
 	}
 	return string(output)
 }
+
+// maxBranchSlug caps the title part of an issue branch name
+const maxBranchSlug = 40
+
+// IssueBranchName returns the branch for an issue: issue/<id>-<slugified title>
+func IssueBranchName(issueID, title string) string {
+	var sb strings.Builder
+	hyphen := false
+	for _, r := range strings.ToLower(title) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if hyphen && sb.Len() > 0 {
+				sb.WriteByte('-')
+			}
+			sb.WriteRune(r)
+			hyphen = false
+		} else {
+			hyphen = true
+		}
+	}
+	slug := sb.String()
+	if len(slug) > maxBranchSlug {
+		slug = slug[:maxBranchSlug]
+		// Cut at a word boundary when there is one
+		if i := strings.LastIndexByte(slug, '-'); i > 0 {
+			slug = slug[:i]
+		}
+	}
+	if slug == "" {
+		return "issue/" + issueID
+	}
+	return "issue/" + issueID + "-" + slug
+}
+
+// CreateIssueBranch switches to the issue's branch (see IssueBranchName), creating it
+// from the current HEAD unless it already exists. Uncommitted changes come along.
+// Returns the branch name, or "" outside a git repository.
+func (s *Storage) CreateIssueBranch(issueID, title string) (string, error) {
+	if !s.IsGitRepo() {
+		return "", nil
+	}
+	branch := IssueBranchName(issueID, title)
+
+	args := []string{"checkout", "-b", branch}
+	exists := exec.Command("git", "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+	exists.Dir = s.ProjectRoot
+	if exists.Run() == nil {
+		args = []string{"checkout", branch}
+	}
+	cmd := exec.Command("git", args...)
+	cmd.Dir = s.ProjectRoot
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git %s: %s", strings.Join(args, " "), strings.TrimSpace(string(output)))
+	}
+	return branch, nil
+}
@@ -0,0 +1,44 @@
+package storage
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// TestCommitIssueFilesCommitsPrunedVersions checks that removing a pruned version
+// is part of the issue's commit, also when the project is a subdirectory of the
+// repository
+func TestCommitIssueFilesCommitsPrunedVersions(t *testing.T) {
+	repo := newGitProject(t)
+	s := New(filepath.Join(repo.ProjectRoot, "service"), "")
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	s.MaxAnalysisVersions = 2
+	id := saveAnalysisVersions(t, s, 2)
+	if ok, output := s.CommitIssueFiles(id, "chore: analyze #"+id); !ok {
+		t.Fatalf("commit failed: %s", output)
+	}
+
+	if err := s.SaveAnalysisVersioned(id, "## Summary\nv3", 3); err != nil {
+		t.Fatal(err)
+	}
+	if ok, output := s.CommitIssueFiles(id, "chore: revise #"+id); !ok {
+		t.Fatalf("commit failed: %s", output)
+	}
+
+	tracked := strings.Split(git(t, repo.ProjectRoot, "ls-files", "service/issues/"+id), "\n")
+	for _, name := range []string{"analysis_v2.md", "analysis_v3.md", ".analysis_version", "brief.md"} {
+		if !slices.Contains(tracked, "service/issues/"+id+"/"+name) {
+			t.Errorf("%s not committed: %q", name, tracked)
+		}
+	}
+	if slices.Contains(tracked, "service/issues/"+id+"/analysis_v1.md") {
+		t.Errorf("pruned analysis_v1.md still committed: %q", tracked)
+	}
+	if staged := git(t, repo.ProjectRoot, "diff", "--cached", "--name-only"); staged != "" {
+		t.Errorf("left staged after the commit: %q", staged)
+	}
+}
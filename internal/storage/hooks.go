@@ -0,0 +1,48 @@
+package storage
+
+import "github.com/lunit-heesungyang/issue-manager/internal/model"
+
+// Hooks are optional callbacks fired after storage changes, for integrations such as
+// notifications or syncing an external tracker. Unset fields are ignored. Hooks are
+// called synchronously and must return quickly: start a goroutine for slow work so
+// the UI is never blocked.
+type Hooks struct {
+	IssueCreated  func(issue *model.Issue)
+	StatusChanged func(issueID string, from, to model.IssueStatus)
+	AnalysisSaved func(issueID string)
+	PlanSaved     func(issueID string)
+	IssueClosed   func(issueID string)
+}
+
+func (s *Storage) fireIssueCreated(issue *model.Issue) {
+	if hook := s.Hooks.IssueCreated; hook != nil {
+		snapshot := *issue
+		hook(&snapshot)
+	}
+}
+
+func (s *Storage) fireStatusChanged(issueID string, from, to model.IssueStatus) {
+	if from == to {
+		return
+	}
+	if hook := s.Hooks.StatusChanged; hook != nil {
+		hook(issueID, from, to)
+	}
+	if to == model.StatusClosed {
+		if hook := s.Hooks.IssueClosed; hook != nil {
+			hook(issueID)
+		}
+	}
+}
+
+func (s *Storage) fireAnalysisSaved(issueID string) {
+	if hook := s.Hooks.AnalysisSaved; hook != nil {
+		hook(issueID)
+	}
+}
+
+func (s *Storage) firePlanSaved(issueID string) {
+	if hook := s.Hooks.PlanSaved; hook != nil {
+		hook(issueID)
+	}
+}
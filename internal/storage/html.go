@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"os"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// htmlTemplate wraps rendered markdown in a minimal readable page
+const htmlTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>%s</title>
+<style>
+body { max-width: 860px; margin: 2em auto; padding: 0 1em; font-family: -apple-system, "Segoe UI", sans-serif; line-height: 1.5; }
+pre, code { background: #f4f4f4; border-radius: 3px; }
+pre { padding: 0.8em; overflow-x: auto; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; }
+</style>
+</head>
+<body>
+%s
+</body>
+</html>
+`
+
+var markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+// RenderHTML renders markdown content to a temporary HTML file and returns its path.
+// Files are tracked and removed by CleanupTempFiles.
+func (s *Storage) RenderHTML(title, content string) (string, error) {
+	var body bytes.Buffer
+	if err := markdown.Convert([]byte(content), &body); err != nil {
+		return "", fmt.Errorf("rendering markdown: %w", err)
+	}
+
+	f, err := os.CreateTemp("", "lfim-*.html")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := fmt.Fprintf(f, htmlTemplate, html.EscapeString(title), body.String()); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+
+	s.tempFiles = append(s.tempFiles, f.Name())
+	return f.Name(), nil
+}
+
+// CleanupTempFiles removes temporary files created during the session
+func (s *Storage) CleanupTempFiles() {
+	for _, path := range s.tempFiles {
+		_ = os.Remove(path)
+	}
+	s.tempFiles = nil
+}
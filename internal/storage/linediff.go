@@ -0,0 +1,44 @@
+package storage
+
+// DiffOp is what happened to a line between two versions of a document
+type DiffOp int
+
+const (
+	DiffKept DiffOp = iota
+	DiffAdded
+	DiffRemoved
+)
+
+// DiffLine is one line of a line-level diff
+type DiffLine struct {
+	Op   DiffOp
+	Text string
+}
+
+// DiffLines returns a line-level diff from old to new, per the longest common
+// subsequence: each removed line comes before the lines that replaced it
+func DiffLines(old, new string) []DiffLine {
+	a, b := splitLines(old), splitLines(new)
+	matches := matchLines(a, b)
+
+	var diff []DiffLine
+	var added []DiffLine
+	i := 0
+	for j, text := range b {
+		if matches[j] < 0 {
+			added = append(added, DiffLine{Op: DiffAdded, Text: text})
+			continue
+		}
+		for ; i < matches[j]; i++ {
+			diff = append(diff, DiffLine{Op: DiffRemoved, Text: a[i]})
+		}
+		diff = append(diff, added...)
+		added = nil
+		diff = append(diff, DiffLine{Op: DiffKept, Text: text})
+		i++
+	}
+	for ; i < len(a); i++ {
+		diff = append(diff, DiffLine{Op: DiffRemoved, Text: a[i]})
+	}
+	return append(diff, added...)
+}
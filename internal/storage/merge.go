@@ -0,0 +1,91 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// MergeIssues folds mergeID into keepID: the merged brief is appended to the canonical
+// brief under a "Merged from" section, and the merged issue is closed as a duplicate.
+// All affected files are staged.
+func (s *Storage) MergeIssues(keepID, mergeID string) error {
+	if keepID == mergeID {
+		return fmt.Errorf("cannot merge %s into itself", keepID)
+	}
+
+	keep, err := s.LoadBrief(keepID)
+	if err != nil {
+		return err
+	}
+	if keep == nil {
+		return fmt.Errorf("issue not found: %s", keepID)
+	}
+	merged, err := s.LoadBrief(mergeID)
+	if err != nil {
+		return err
+	}
+	if merged == nil {
+		return fmt.Errorf("issue not found: %s", mergeID)
+	}
+
+	var section strings.Builder
+	fmt.Fprintf(&section, "## Merged from #%s: %s\n\n", merged.ID, merged.Title)
+	if merged.Content != "" {
+		section.WriteString(merged.Content)
+		section.WriteString("\n")
+	}
+	// Point at the merged issue's notes rather than copying them
+	var notes []string
+	if s.AnalysisExists(mergeID) || s.AnalysisJSONExists(mergeID) {
+		notes = append(notes, s.relPath(s.AnalysisPath(mergeID)))
+	}
+	if s.PlanExists(mergeID) {
+		notes = append(notes, s.relPath(s.PlanPath(mergeID)))
+	}
+	if len(notes) > 0 {
+		fmt.Fprintf(&section, "\nNotes: %s\n", strings.Join(notes, ", "))
+	}
+
+	keep.Content = strings.TrimRight(keep.Content, "\n") + "\n\n" + section.String()
+	if err := s.SaveBrief(keep); err != nil {
+		return err
+	}
+
+	previous := merged.Status
+	merged.Status = model.StatusClosed
+	merged.DuplicateOf = keepID
+	merged.Closed = time.Now()
+	if err := s.SaveBrief(merged); err != nil {
+		return err
+	}
+
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return err
+	}
+	if idxIssue := idx.GetIssue(mergeID); idxIssue != nil {
+		idxIssue.Status = model.StatusClosed
+		idxIssue.Closed = merged.Closed
+		idx.UpdateIssue(idxIssue)
+		if err := s.SaveIndex(idx); err != nil {
+			return err
+		}
+	}
+
+	s.gitAdd(s.IndexPath(), s.BriefPath(keepID), s.BriefPath(mergeID))
+	s.fireStatusChanged(mergeID, previous, model.StatusClosed)
+	return nil
+}
+
+// relPath returns path relative to the project root, or path itself if that fails
+func (s *Storage) relPath(path string) string {
+	rel, err := filepath.Rel(s.ProjectRoot, path)
+	if err != nil {
+		return path
+	}
+	return rel
+}
@@ -0,0 +1,320 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// Fixtures shared by the storage tests: a throwaway git project and a fake claude
+// CLI that answers with the canned responses in testdata/claude.
+
+// newGitProject returns storage for a fresh git repository with one commit
+func newGitProject(t *testing.T) *Storage {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	t.Setenv("GIT_AUTHOR_NAME", "Test")
+	t.Setenv("GIT_AUTHOR_EMAIL", "test@example.com")
+	t.Setenv("GIT_COMMITTER_NAME", "Test")
+	t.Setenv("GIT_COMMITTER_EMAIL", "test@example.com")
+	t.Setenv("GIT_CONFIG_GLOBAL", os.DevNull)
+
+	writeFile(t, filepath.Join(dir, "README.md"), "# project\n")
+	git(t, dir, "init", "-q")
+	git(t, dir, "add", "-A")
+	git(t, dir, "commit", "-q", "-m", "init")
+	return New(dir, "")
+}
+
+// git runs a git command in dir and returns its trimmed output
+func git(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %v\n%s", strings.Join(args, " "), err, output)
+	}
+	return strings.TrimSpace(string(output))
+}
+
+func writeFile(t testing.TB, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// fakeClaude is a claude CLI stand-in whose next answer is set with respond
+type fakeClaude struct {
+	t        *testing.T
+	client   *claude.Client
+	argsPath string
+}
+
+// newFakeClaude returns a client for the project with testdata/claude/fake-claude.sh
+// installed as the claude on PATH
+func newFakeClaude(t *testing.T, projectRoot string) *fakeClaude {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("fake claude is a shell script")
+	}
+	script, err := os.ReadFile(filepath.Join("testdata", "claude", "fake-claude.sh"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bin := t.TempDir()
+	if err := os.WriteFile(filepath.Join(bin, "claude"), script, 0755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	f := &fakeClaude{t: t, client: claude.New(projectRoot), argsPath: filepath.Join(t.TempDir(), "args")}
+	t.Setenv("FAKE_CLAUDE_ARGS", f.argsPath)
+	return f
+}
+
+// respond makes the fake answer with testdata/claude/<name> until changed
+func (f *fakeClaude) respond(name string) {
+	f.t.Helper()
+	path, err := filepath.Abs(filepath.Join("testdata", "claude", name))
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	f.t.Setenv("FAKE_CLAUDE_RESPONSE", path)
+}
+
+// lastArgs returns the arguments of the most recent call
+func (f *fakeClaude) lastArgs() []string {
+	f.t.Helper()
+	data, err := os.ReadFile(f.argsPath)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	return strings.Split(strings.TrimSuffix(string(data), "\x00"), "\x00")
+}
+
+// hasFlag reports whether the most recent call passed flag with value
+func (f *fakeClaude) hasFlag(flag, value string) bool {
+	args := f.lastArgs()
+	i := slices.Index(args, flag)
+	return i >= 0 && i+1 < len(args) && args[i+1] == value
+}
+
+// run makes one async call, as the TUI does, and waits for its result
+func (f *fakeClaude) run(issueID, taskType, prompt, session string) claude.TaskResult {
+	f.t.Helper()
+	results := make(chan claude.TaskResult, 1)
+	f.client.RunAsync(context.Background(), issueID, taskType, prompt, "", session, results)
+	result := <-results
+	if !result.Success {
+		f.t.Fatalf("%s %s failed: %s", taskType, issueID, result.Result)
+	}
+	return result
+}
+
+// fixtureResult returns the result text of a canned response
+func fixtureResult(t *testing.T, name string) string {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", "claude", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var response struct {
+		Result string `json:"result"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		t.Fatal(err)
+	}
+	return response.Result
+}
+
+// assertStatus checks the issue's status in both index.yaml and brief.md
+func assertStatus(t *testing.T, s *Storage, issueID string, want model.IssueStatus) {
+	t.Helper()
+	idx, err := s.LoadIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry := idx.GetIssue(issueID)
+	if entry == nil {
+		t.Fatalf("%s missing from the index", issueID)
+	}
+	if entry.Status != want {
+		t.Errorf("index status of %s = %s, want %s", issueID, entry.Status, want)
+	}
+	brief, err := s.LoadBrief(issueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if brief.Status != want {
+		t.Errorf("brief status of %s = %s, want %s", issueID, brief.Status, want)
+	}
+}
+
+func assertContent(t *testing.T, path, want string) {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != want {
+		t.Errorf("%s = %q, want %q", filepath.Base(path), got, want)
+	}
+}
+
+// TestPipeline drives an issue through create → analyze → plan → implement → close
+// with a fake claude, checking the files, the index and the close commit
+func TestPipeline(t *testing.T) {
+	s := newGitProject(t)
+	fake := newFakeClaude(t, s.ProjectRoot)
+
+	// Create
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	issue, err := s.CreateIssue("Login drops the session", model.TypeBug, model.PriorityHigh, "Logging in and then redirecting loses the session.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if issue.ID != "0001" {
+		t.Errorf("first issue ID = %s, want 0001", issue.ID)
+	}
+	assertStatus(t, s, issue.ID, model.StatusOpen)
+
+	// Analyze: markdown output becomes analysis.md and the session is kept
+	fake.respond("analysis.json")
+	prompt := claude.BuildAnalysisPrompt(issue.Content, s.BriefPath(issue.ID), issue.Type)
+	result := fake.run(issue.ID, "analyze", prompt, "")
+	if err := s.AddUsage(issue.ID, result.Usage); err != nil {
+		t.Fatal(err)
+	}
+	structured, err := s.SaveAnalysisResult(issue.ID, result.Result, result.SessionID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if structured {
+		t.Error("markdown analysis saved as structured")
+	}
+	assertContent(t, s.AnalysisPath(issue.ID), fixtureResult(t, "analysis.json"))
+	if session, _ := s.LoadSessionID(issue.ID); session != "sess-analysis" {
+		t.Errorf("session = %q, want sess-analysis", session)
+	}
+	assertStatus(t, s, issue.ID, model.StatusAnalyzed)
+
+	// Plan: resumes the analysis session and saves plan v1
+	fake.respond("plan.json")
+	analysis, err := s.LoadAnalysis(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, _ := s.LoadSessionID(issue.ID)
+	result = fake.run(issue.ID, "plan", claude.BuildPlanPrompt(issue.Content, analysis), session)
+	if !fake.hasFlag("--resume", "sess-analysis") {
+		t.Errorf("plan call did not resume the analysis session: %q", fake.lastArgs())
+	}
+	version, err := s.SavePlanRevision(issue.ID, result.Result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 1 {
+		t.Errorf("plan version = %d, want 1", version)
+	}
+	if err := s.UpdateIssueStatus(issue.ID, model.StatusPlanned, ""); err != nil {
+		t.Fatal(err)
+	}
+	assertContent(t, s.PlanPath(issue.ID), fixtureResult(t, "plan.json"))
+	assertStatus(t, s, issue.ID, model.StatusPlanned)
+
+	// Implement: headless, allowed to edit files, on the same session
+	fake.respond("implement.json")
+	s.StageIssueFiles(issue.ID)
+	result = fake.client.RunImplement(claude.BuildImplementPrompt(s.PlanPath(issue.ID)), session)
+	if !result.Success {
+		t.Fatal("implement failed")
+	}
+	if err := s.AddUsage(issue.ID, result.Usage); err != nil {
+		t.Fatal(err)
+	}
+	// The analysis and the implementation each recorded a call
+	if usage, err := s.LoadUsage(issue.ID); err != nil || usage.Calls != 2 {
+		t.Errorf("usage = %+v, %v; want 2 calls", usage, err)
+	}
+	if !fake.hasFlag("--permission-mode", "acceptEdits") || !fake.hasFlag("--resume", "sess-analysis") {
+		t.Errorf("implement args = %q", fake.lastArgs())
+	}
+	writeFile(t, filepath.Join(s.ProjectRoot, "auth", "redirect.go"), "package auth\n")
+	git(t, s.ProjectRoot, "add", "auth/redirect.go")
+	if err := s.UpdateIssueStatus(issue.ID, model.StatusImplemented, ""); err != nil {
+		t.Fatal(err)
+	}
+	assertStatus(t, s, issue.ID, model.StatusImplemented)
+
+	// Close issue-only: a chore commit with the issue's files, the code left staged.
+	// Runtime files stay out of it.
+	for _, name := range []string{".claude.log", ".review.rejected.md", ".analysis.partial.md", ".brief.md.tmp-123"} {
+		writeFile(t, filepath.Join(s.IssueDir(issue.ID), name), "runtime\n")
+	}
+	if err := s.UpdateIssueStatus(issue.ID, model.StatusClosed, ""); err != nil {
+		t.Fatal(err)
+	}
+	if ok, output := s.CommitIssueFiles(issue.ID, "chore: close #"+issue.ID); !ok {
+		t.Fatalf("commit failed: %s", output)
+	}
+	assertStatus(t, s, issue.ID, model.StatusClosed)
+
+	if subject := git(t, s.ProjectRoot, "log", "-1", "--format=%s"); subject != "chore: close #0001" {
+		t.Errorf("commit subject = %q", subject)
+	}
+	committed := strings.Split(git(t, s.ProjectRoot, "show", "--name-only", "--format=", "HEAD"), "\n")
+	for _, want := range []string{
+		"issues/0001/brief.md",
+		"issues/0001/analysis.md",
+		"issues/0001/plan.md",
+		"issues/0001/plan_v1.md",
+		"issues/0001/.usage.json",
+		"issues/index.yaml",
+	} {
+		if !slices.Contains(committed, want) {
+			t.Errorf("close commit is missing %s (has %v)", want, committed)
+		}
+	}
+	for _, unwanted := range []string{
+		"auth/redirect.go",
+		"issues/0001/.session",
+		"issues/0001/.claude.log",
+		"issues/0001/.review.rejected.md",
+		"issues/0001/.analysis.partial.md",
+		"issues/0001/.brief.md.tmp-123",
+	} {
+		if slices.Contains(committed, unwanted) {
+			t.Errorf("close commit includes %s", unwanted)
+		}
+	}
+	if staged := git(t, s.ProjectRoot, "diff", "--cached", "--name-only"); staged != "auth/redirect.go" {
+		t.Errorf("staged after close = %q, want the code change only", staged)
+	}
+
+	idx, err := s.LoadIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if closed := idx.GetIssue(issue.ID).Closed; closed.IsZero() {
+		t.Error("closed issue has no close time")
+	}
+}
@@ -0,0 +1,233 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// LoadPlanSections parses plan.md into its sections, task list and file table
+func (s *Storage) LoadPlanSections(issueID string) (*model.PlanStructure, error) {
+	content, err := s.LoadPlan(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if content == "" {
+		return nil, fmt.Errorf("no plan for %s", issueID)
+	}
+	return model.ParsePlan(content), nil
+}
+
+// PlanStaging reports how a plan's Files Modified table matched the working tree
+type PlanStaging struct {
+	Staged    []string // listed files with changes, now staged
+	Missing   []string // listed files that don't exist
+	Unchanged []string // listed files without changes
+	Unlisted  []string // changed files the table doesn't mention
+}
+
+// Paths returns the files a plan-scoped commit should include: the staged plan
+// files and the issue's artifacts, the same ones an issue-only close commits
+func (p *PlanStaging) Paths(s *Storage, issueID string) []string {
+	paths := append([]string{}, p.Staged...)
+	paths = append(paths, s.IssueArtifactPaths(issueID)...)
+	return append(paths, s.stagedRemovals(s.IssueDir(issueID))...)
+}
+
+// StagePlanFiles stages exactly the files listed in the plan's Files Modified table
+// that have changes, resolving paths against the project root
+func (s *Storage) StagePlanFiles(issueID string) (*PlanStaging, error) {
+	plan, err := s.LoadPlanSections(issueID)
+	if err != nil {
+		return nil, err
+	}
+	if len(plan.Files) == 0 {
+		return nil, fmt.Errorf("plan for %s has no Files Modified table", issueID)
+	}
+
+	changed := s.workingChanges()
+	listed := make(map[string]bool)
+	staging := &PlanStaging{}
+	for _, f := range plan.Files {
+		path := s.projectRelative(f.Path)
+		if listed[path] {
+			continue
+		}
+		listed[path] = true
+
+		_, statErr := os.Stat(filepath.Join(s.ProjectRoot, path))
+		switch {
+		case changed[path] != nil:
+			// Includes deletions, which git add -A stages
+			staging.Staged = append(staging.Staged, path)
+		case os.IsNotExist(statErr):
+			staging.Missing = append(staging.Missing, path)
+		default:
+			staging.Unchanged = append(staging.Unchanged, path)
+		}
+	}
+
+	for path := range changed {
+		if !listed[path] && !s.isIssuePath(path) {
+			staging.Unlisted = append(staging.Unlisted, path)
+		}
+	}
+	sort.Strings(staging.Unlisted)
+
+	if len(staging.Staged) > 0 {
+		args := append([]string{"add", "-A", "--"}, staging.Staged...)
+		cmd := exec.Command("git", args...)
+		cmd.Dir = s.ProjectRoot
+		if output, err := cmd.CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("git add: %s", strings.TrimSpace(string(output)))
+		}
+	}
+	return staging, nil
+}
+
+// projectRelative converts a plan path to a clean path relative to the project root
+func (s *Storage) projectRelative(path string) string {
+	if filepath.IsAbs(path) {
+		if rel, err := filepath.Rel(s.absRoot(), path); err == nil {
+			path = rel
+		}
+	}
+	return filepath.Clean(path)
+}
+
+func (s *Storage) absRoot() string {
+	if abs, err := filepath.Abs(s.ProjectRoot); err == nil {
+		return abs
+	}
+	return s.ProjectRoot
+}
+
+// FileChange is a changed file with its diff line counts. New untracked files have
+// no counts.
+type FileChange struct {
+	Path      string
+	Added     int
+	Deleted   int
+	Untracked bool
+}
+
+// PlanDiffReport compares the files a plan meant to change with the working tree
+type PlanDiffReport struct {
+	Changed   []FileChange // planned files that changed
+	Untouched []string     // planned files with no changes
+	Unplanned []FileChange // changed files the plan doesn't mention
+}
+
+// Matches reports whether the working tree changed exactly the planned files
+func (r *PlanDiffReport) Matches() bool {
+	return len(r.Untouched) == 0 && len(r.Unplanned) == 0
+}
+
+// ComparePlanToDiff matches the plan's Files Modified table (or, without one, the
+// files named in its tasks) against uncommitted changes. Read-only.
+func (s *Storage) ComparePlanToDiff(issueID string) (*PlanDiffReport, error) {
+	plan, err := s.LoadPlanSections(issueID)
+	if err != nil {
+		return nil, err
+	}
+
+	var planned []string
+	for _, f := range plan.Files {
+		planned = append(planned, f.Path)
+	}
+	if len(planned) == 0 {
+		planned = plan.FilePaths()
+	}
+	if len(planned) == 0 {
+		return nil, fmt.Errorf("plan for %s names no files", issueID)
+	}
+
+	changed := s.workingChanges()
+	report := &PlanDiffReport{}
+	listed := make(map[string]bool)
+	for _, path := range planned {
+		path = s.projectRelative(path)
+		if listed[path] {
+			continue
+		}
+		listed[path] = true
+		if change := changed[path]; change != nil {
+			report.Changed = append(report.Changed, *change)
+		} else {
+			report.Untouched = append(report.Untouched, path)
+		}
+	}
+	for path, change := range changed {
+		if !listed[path] && !s.isIssuePath(path) {
+			report.Unplanned = append(report.Unplanned, *change)
+		}
+	}
+	sort.Slice(report.Unplanned, func(i, j int) bool {
+		return report.Unplanned[i].Path < report.Unplanned[j].Path
+	})
+	return report, nil
+}
+
+// diffBase returns what uncommitted changes are compared against: HEAD, or the
+// empty tree in a repository without commits
+func (s *Storage) diffBase() string {
+	cmd := exec.Command("git", "rev-parse", "--verify", "--quiet", "HEAD")
+	cmd.Dir = s.ProjectRoot
+	if cmd.Run() == nil {
+		return "HEAD"
+	}
+	cmd = exec.Command("git", "hash-object", "-t", "tree", "--stdin")
+	cmd.Dir = s.ProjectRoot
+	cmd.Stdin = strings.NewReader("")
+	if output, err := cmd.Output(); err == nil {
+		return strings.TrimSpace(string(output))
+	}
+	return "HEAD"
+}
+
+// isIssuePath reports whether a project-relative path is inside the issues directory
+func (s *Storage) isIssuePath(path string) bool {
+	issuesDir, err := filepath.Rel(s.ProjectRoot, s.IssuesDir)
+	return err == nil && strings.HasPrefix(path, issuesDir+string(filepath.Separator))
+}
+
+// workingChanges returns tracked files that differ from HEAD (with line counts) and
+// untracked files, keyed by path relative to the project root. Before the first
+// commit every tracked file counts as added.
+func (s *Storage) workingChanges() map[string]*FileChange {
+	changed := make(map[string]*FileChange)
+
+	cmd := exec.Command("git", "diff", "--numstat", "--relative", s.diffBase())
+	cmd.Dir = s.ProjectRoot
+	if output, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			// added<TAB>deleted<TAB>path; binary files report "-" counts
+			fields := strings.SplitN(line, "\t", 3)
+			if len(fields) != 3 {
+				continue
+			}
+			change := &FileChange{Path: filepath.Clean(fields[2])}
+			change.Added, _ = strconv.Atoi(fields[0])
+			change.Deleted, _ = strconv.Atoi(fields[1])
+			changed[change.Path] = change
+		}
+	}
+
+	cmd = exec.Command("git", "ls-files", "--others", "--exclude-standard")
+	cmd.Dir = s.ProjectRoot
+	if output, err := cmd.Output(); err == nil {
+		for _, line := range strings.Split(string(output), "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				path := filepath.Clean(line)
+				changed[path] = &FileChange{Path: path, Untracked: true}
+			}
+		}
+	}
+	return changed
+}
@@ -0,0 +1,211 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// loadPlanFixture saves testdata/plans/<name> as the plan of a new issue and
+// parses it back through LoadPlanSections
+func loadPlanFixture(t *testing.T, name string) *model.PlanStructure {
+	t.Helper()
+	s := newProject(t)
+	copyFixture(t, "plans/"+name, s.PlanPath("0001"))
+	plan, err := s.LoadPlanSections("0001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plan
+}
+
+// TestLoadPlanSectionsNumbered reads a plan in the format the plan prompt asks for:
+// numbered tasks with File/Changes sub-bullets, a files table, a fenced snippet and
+// the change log after a rule
+func TestLoadPlanSectionsNumbered(t *testing.T) {
+	plan := loadPlanFixture(t, "numbered.md")
+
+	wantOrder := []string{"Plan Summary", "Implementation Tasks", "Files Modified", "Testing Approach", "Risk Mitigation", "Change Log"}
+	if !reflect.DeepEqual(plan.Order, wantOrder) {
+		t.Errorf("sections = %q, want %q", plan.Order, wantOrder)
+	}
+	if len(plan.Summary) != 3 || plan.Summary[0] != "Copy the session cookie onto the redirect response in `auth.Redirect`" {
+		t.Errorf("summary = %q", plan.Summary)
+	}
+
+	wantTasks := []model.PlanTask{
+		{
+			Number:  1,
+			Title:   "Copy cookies on redirect",
+			Files:   []string{"internal/auth/redirect.go"},
+			Changes: "Copy `Set-Cookie` headers from the login response before writing the 302",
+			Details: []string{"Keep the existing `Location` handling as is"},
+		},
+		{
+			Number:  2,
+			Title:   "Add a regression test",
+			Files:   []string{"internal/auth/redirect_test.go", "internal/auth/testdata/login.http"},
+			Changes: "Log in, follow the redirect, assert the session survives",
+		},
+	}
+	if !reflect.DeepEqual(plan.Tasks, wantTasks) {
+		t.Errorf("tasks =\n%+v\nwant\n%+v", plan.Tasks, wantTasks)
+	}
+
+	wantFiles := []model.PlanFile{
+		{Path: "internal/auth/redirect.go", Changes: "Copy cookies onto the redirect"},
+		{Path: "internal/auth/redirect_test.go", Changes: "New regression test"},
+	}
+	if !reflect.DeepEqual(plan.Files, wantFiles) {
+		t.Errorf("files = %+v, want %+v", plan.Files, wantFiles)
+	}
+	wantPaths := []string{"internal/auth/redirect.go", "internal/auth/redirect_test.go", "internal/auth/testdata/login.http"}
+	if got := plan.FilePaths(); !reflect.DeepEqual(got, wantPaths) {
+		t.Errorf("FilePaths() = %q, want %q", got, wantPaths)
+	}
+
+	approach := plan.Section("Testing Approach")
+	if !strings.Contains(approach, "## not a section: inside a code fence") {
+		t.Errorf("fenced heading split the Testing Approach section: %q", approach)
+	}
+	if risk := plan.Section("Risk Mitigation"); strings.HasSuffix(risk, "---") {
+		t.Errorf("Risk Mitigation kept the rule before the change log: %q", risk)
+	}
+	if log := plan.Section("Change Log"); !strings.Contains(log, "### [Revision 1]") {
+		t.Errorf("change log = %q", log)
+	}
+}
+
+// TestLoadPlanSectionsHeadings reads a plan that drifted from the prompt's format:
+// renamed sections, tasks as ### headings and the files as a bullet list
+func TestLoadPlanSectionsHeadings(t *testing.T) {
+	plan := loadPlanFixture(t, "headings.md")
+
+	if want := []string{"Move the retry loop into the client", "Make the backoff configurable"}; !reflect.DeepEqual(plan.Summary, want) {
+		t.Errorf("summary = %q, want %q", plan.Summary, want)
+	}
+	wantTasks := []model.PlanTask{
+		{Number: 1, Title: "Extract the retry loop", Files: []string{"internal/client/retry.go"}, Changes: "Move `retry` out of the handler and export `Retry`"},
+		{Number: 2, Title: "Add a backoff setting", Files: []string{"internal/config/config.go", "README.md"}, Changes: "Add `retry.backoff` with a 1s default"},
+	}
+	if !reflect.DeepEqual(plan.Tasks, wantTasks) {
+		t.Errorf("tasks =\n%+v\nwant\n%+v", plan.Tasks, wantTasks)
+	}
+	wantFiles := []model.PlanFile{
+		{Path: "internal/client/retry.go", Changes: "new home of the retry loop"},
+		{Path: "internal/config/config.go", Changes: "backoff setting"},
+		{Path: "README.md", Changes: "document the setting"},
+	}
+	if !reflect.DeepEqual(plan.Files, wantFiles) {
+		t.Errorf("files = %+v, want %+v", plan.Files, wantFiles)
+	}
+}
+
+func TestLoadPlanSectionsWithoutPlan(t *testing.T) {
+	s := newProject(t)
+	if plan, err := s.LoadPlanSections("0001"); err == nil {
+		t.Errorf("missing plan parsed as %+v", plan)
+	}
+}
+
+// newUnbornGitProject returns storage for a git repository without commits
+func newUnbornGitProject(t *testing.T) *Storage {
+	t.Helper()
+	s := newGitProject(t)
+	if err := os.RemoveAll(filepath.Join(s.ProjectRoot, ".git")); err != nil {
+		t.Fatal(err)
+	}
+	git(t, s.ProjectRoot, "init", "-q")
+	return s
+}
+
+func TestStagePlanFilesBeforeFirstCommit(t *testing.T) {
+	s := newUnbornGitProject(t)
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	issue, err := s.CreateIssue("First feature", model.TypeFeature, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SavePlan(issue.ID, "## Files Modified\n| File | Changes |\n|---|---|\n| main.go | entry point |\n| util.go | helpers |\n| README.md | docs |\n"); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(s.ProjectRoot, "main.go"), "package main\n")
+	writeFile(t, filepath.Join(s.ProjectRoot, "util.go"), "package main\n")
+	git(t, s.ProjectRoot, "add", "main.go")
+
+	staging, err := s.StagePlanFiles(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// main.go is tracked (staged), util.go untracked, README.md never added
+	want := []string{"main.go", "util.go", "README.md"}
+	if !reflect.DeepEqual(staging.Staged, want) {
+		t.Errorf("staged = %q, want %q (unchanged %q)", staging.Staged, want, staging.Unchanged)
+	}
+}
+
+// TestPlanCloseCommitsIssueArtifacts checks that a plan-mode close commits the same
+// issue files as an issue-only close
+func TestPlanCloseCommitsIssueArtifacts(t *testing.T) {
+	s := newGitProject(t)
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	issue, err := s.CreateIssue("Planned", model.TypeBug, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SaveAnalysisResult(issue.ID, "## Summary\nv1", "sess-1"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SaveAnalysisRevision(issue.ID, "## Summary\nv2"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.SavePlanRevision(issue.ID, "## Files Modified\n| File | Changes |\n|---|---|\n| fix.go | the fix |\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.AddUsage(issue.ID, model.Usage{InputTokens: 10}); err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, filepath.Join(s.IssueDir(issue.ID), ".claude.log"), "warning\n")
+	writeFile(t, filepath.Join(s.ProjectRoot, "fix.go"), "package fix\n")
+
+	staging, err := s.StagePlanFiles(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	paths := staging.Paths(s, issue.ID)
+	s.StageIssueFiles(issue.ID)
+	if ok, output := s.CommitPaths("fix: the bug", paths); !ok {
+		t.Fatalf("commit failed: %s", output)
+	}
+
+	committed := strings.Split(git(t, s.ProjectRoot, "show", "--name-only", "--format=", "HEAD"), "\n")
+	var want []string
+	for _, path := range append([]string{filepath.Join(s.ProjectRoot, "fix.go")}, s.IssueArtifactPaths(issue.ID)...) {
+		rel, _ := filepath.Rel(s.ProjectRoot, path)
+		want = append(want, filepath.ToSlash(rel))
+	}
+	slices.Sort(committed)
+	slices.Sort(want)
+	if !reflect.DeepEqual(committed, want) {
+		t.Errorf("committed %q, want the plan file and the issue's artifacts %q", committed, want)
+	}
+	for _, name := range []string{"analysis_v2.md", "plan_v1.md", ".analysis_version", ".plan_version", ".usage.json"} {
+		if !slices.Contains(committed, "issues/"+issue.ID+"/"+name) {
+			t.Errorf("plan close left out %s", name)
+		}
+	}
+	for _, name := range []string{".session", ".claude.log"} {
+		if slices.Contains(committed, "issues/"+issue.ID+"/"+name) {
+			t.Errorf("plan close committed %s", name)
+		}
+	}
+}
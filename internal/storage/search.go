@@ -0,0 +1,69 @@
+package storage
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// SearchResult is a single matching line in an issue document
+type SearchResult struct {
+	IssueID string
+	File    string // base name, e.g. "analysis.md"
+	Line    int    // 1-based
+	Text    string
+}
+
+// Search scans every issue's brief, analysis and plan for query, case-insensitively.
+// With useRegex the query is a regular expression. Binary files are skipped.
+func (s *Storage) Search(query string, useRegex bool) ([]SearchResult, error) {
+	match, err := lineMatcher(query, useRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.ListIssueIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SearchResult
+	for _, id := range ids {
+		for _, path := range []string{s.BriefPath(id), s.AnalysisPath(id), s.PlanPath(id)} {
+			data, err := os.ReadFile(path)
+			if err != nil || bytes.IndexByte(data, 0) >= 0 {
+				continue
+			}
+			for i, line := range strings.Split(s.decodeText(path, data), "\n") {
+				if match(line) {
+					results = append(results, SearchResult{
+						IssueID: id,
+						File:    filepath.Base(path),
+						Line:    i + 1,
+						Text:    strings.TrimSpace(line),
+					})
+				}
+			}
+		}
+	}
+	return results, nil
+}
+
+// lineMatcher returns a case-insensitive predicate for query
+func lineMatcher(query string, useRegex bool) (func(string) bool, error) {
+	if useRegex {
+		re, err := regexp.Compile("(?i)" + query)
+		if err != nil {
+			return nil, fmt.Errorf("invalid regex: %w", err)
+		}
+		return re.MatchString, nil
+	}
+
+	lower := strings.ToLower(query)
+	return func(line string) bool {
+		return strings.Contains(strings.ToLower(line), lower)
+	}, nil
+}
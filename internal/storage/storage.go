@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/lunit-heesungyang/issue-manager/internal/model"
@@ -15,16 +18,40 @@ import (
 type Storage struct {
 	ProjectRoot string
 	IssuesDir   string
+
+	// Hooks are optional integration callbacks. The TUI and CLI set StatusChanged
+	// through notify.Attach when the user config has hooks.
+	Hooks Hooks
+
+	// MaxAnalysisVersions caps the analysis_vN.md files kept per issue; older ones
+	// are deleted when a new version is saved. 0 keeps all; the current and previous
+	// version are always kept.
+	MaxAnalysisVersions int
+
+	cache     *statCache
+	tempFiles []string // rendered HTML previews, removed on exit
+	warnings  textWarnings
 }
 
-// New creates a new Storage instance
-func New(projectRoot string) *Storage {
+// DefaultIssuesDir is the issues directory name used when none is configured
+const DefaultIssuesDir = "issues"
+
+// New creates a new Storage instance. issuesDir is resolved against the project
+// root unless absolute; empty means DefaultIssuesDir.
+func New(projectRoot, issuesDir string) *Storage {
 	if projectRoot == "" {
 		projectRoot, _ = os.Getwd()
 	}
+	if issuesDir == "" {
+		issuesDir = DefaultIssuesDir
+	}
+	if !filepath.IsAbs(issuesDir) {
+		issuesDir = filepath.Join(projectRoot, issuesDir)
+	}
 	return &Storage{
 		ProjectRoot: projectRoot,
-		IssuesDir:   filepath.Join(projectRoot, "issues"),
+		IssuesDir:   issuesDir,
+		cache:       newStatCache(),
 	}
 }
 
@@ -58,6 +85,10 @@ func (s *Storage) SessionPath(issueID string) string {
 	return filepath.Join(s.IssueDir(issueID), ".session")
 }
 
+func (s *Storage) ClaudeLogPath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), ".claude.log")
+}
+
 func (s *Storage) AnalysisVersionPath(issueID string, version int) string {
 	return filepath.Join(s.IssueDir(issueID), fmt.Sprintf("analysis_v%d.md", version))
 }
@@ -66,36 +97,97 @@ func (s *Storage) VersionTrackerPath(issueID string) string {
 	return filepath.Join(s.IssueDir(issueID), ".analysis_version")
 }
 
+func (s *Storage) PlanVersionPath(issueID string, version int) string {
+	return filepath.Join(s.IssueDir(issueID), fmt.Sprintf("plan_v%d.md", version))
+}
+
+func (s *Storage) PlanVersionTrackerPath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), ".plan_version")
+}
+
 func (s *Storage) AnalysisJSONPath(issueID string) string {
 	return filepath.Join(s.IssueDir(issueID), "analysis.json")
 }
 
+func (s *Storage) FeedbackPath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), "feedback.md")
+}
+
+// PartialAnalysisPath is where an in-progress analysis or review streams its output.
+// It survives a crash or cancel and is removed once the final result is saved.
+func (s *Storage) PartialAnalysisPath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), ".analysis.partial.md")
+}
+
+// HasPartialAnalysis reports whether an interrupted run left partial output
+func (s *Storage) HasPartialAnalysis(issueID string) bool {
+	info, err := os.Stat(s.PartialAnalysisPath(issueID))
+	return err == nil && info.Size() > 0
+}
+
+// ClearPartialAnalysis removes streamed partial output
+func (s *Storage) ClearPartialAnalysis(issueID string) {
+	_ = os.Remove(s.PartialAnalysisPath(issueID))
+}
+
+// RejectedReviewPath is where a review result that dropped sections of the
+// analysis or plan is kept instead of replacing it
+func (s *Storage) RejectedReviewPath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), ".review.rejected.md")
+}
+
+// SaveRejectedReview keeps a review result that wasn't saved as a new version
+func (s *Storage) SaveRejectedReview(issueID, content string) error {
+	return writeFileAtomic(s.RejectedReviewPath(issueID), []byte(content), 0644)
+}
+
 // LoadIndex loads the issue index from index.yaml
 func (s *Storage) LoadIndex() (*model.IssueIndex, error) {
+	idx, duplicates, err := s.readIndex()
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range duplicates {
+		s.warnOnce("duplicate:"+id, fmt.Sprintf("index.yaml lists %s more than once; using the first entry (lfim doctor --fix removes the others)", id))
+	}
+	return idx, nil
+}
+
+// readIndex parses index.yaml. An ID listed more than once (e.g. after a bad merge)
+// keeps only its first entry, so lookups and updates can't act on a shadowed copy;
+// the IDs whose later entries were dropped are returned.
+func (s *Storage) readIndex() (*model.IssueIndex, []string, error) {
 	data, err := os.ReadFile(s.IndexPath())
 	if os.IsNotExist(err) {
-		return model.NewIssueIndex(), nil
+		return model.NewIssueIndex(), nil, nil
 	}
 	if err != nil {
-		return nil, fmt.Errorf("reading index: %w", err)
+		return nil, nil, fmt.Errorf("reading index: %w", err)
 	}
 
 	var raw struct {
 		Issues []map[string]interface{} `yaml:"issues"`
 	}
 	if err := yaml.Unmarshal(data, &raw); err != nil {
-		return nil, fmt.Errorf("parsing index: %w", err)
+		return nil, nil, fmt.Errorf("parsing index: %w", err)
 	}
 
 	idx := model.NewIssueIndex()
+	var duplicates []string
 	for _, item := range raw.Issues {
 		issue, err := s.issueFromMap(item)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+		if idx.GetIssue(issue.ID) != nil {
+			if !slices.Contains(duplicates, issue.ID) {
+				duplicates = append(duplicates, issue.ID)
+			}
+			continue
 		}
 		idx.AddIssue(issue)
 	}
-	return idx, nil
+	return idx, duplicates, nil
 }
 
 // SaveIndex saves the issue index to index.yaml
@@ -109,7 +201,7 @@ func (s *Storage) SaveIndex(idx *model.IssueIndex) error {
 		return fmt.Errorf("marshaling index: %w", err)
 	}
 
-	return os.WriteFile(s.IndexPath(), data, 0644)
+	return writeFileAtomic(s.IndexPath(), data, 0644)
 }
 
 // LoadBrief loads an issue from its brief.md file
@@ -122,7 +214,7 @@ func (s *Storage) LoadBrief(issueID string) (*model.Issue, error) {
 		return nil, fmt.Errorf("reading brief: %w", err)
 	}
 
-	fm, body, err := ParseFrontmatter(string(data))
+	fm, body, err := ParseFrontmatter(s.decodeText(s.BriefPath(issueID), data))
 	if err != nil {
 		return nil, err
 	}
@@ -135,16 +227,21 @@ func (s *Storage) LoadBrief(issueID string) (*model.Issue, error) {
 	issue.Title = GetString(fm, "title")
 	issue.Type = model.IssueType(GetString(fm, "type"))
 	issue.Status = model.IssueStatus(GetString(fm, "status"))
+	issue.Priority = model.ParsePriority(GetString(fm, "priority"))
+	issue.Labels = GetStringSlice(fm, "labels")
+	issue.Blocks = GetIDSlice(fm, "blocks")
+	issue.BlockedBy = GetIDSlice(fm, "blocked_by")
 	issue.DiscardReason = GetString(fm, "discard_reason")
+	issue.DuplicateOf = GetString(fm, "duplicate_of")
 
-	if dateStr := GetString(fm, "date"); dateStr != "" {
-		issue.Created, _ = time.Parse("2006-01-02", dateStr)
-	}
+	issue.Created = GetTime(fm, "date")
+	issue.Updated = issue.Created // the real time is only in index.yaml
+	issue.Closed = GetTime(fm, "closed")
 
 	return issue, nil
 }
 
-// SaveBrief saves an issue to its brief.md file
+// SaveBrief saves an issue to its brief.md file and records the update in index.yaml
 func (s *Storage) SaveBrief(issue *model.Issue) error {
 	if err := os.MkdirAll(s.IssueDir(issue.ID), 0755); err != nil {
 		return fmt.Errorf("creating issue dir: %w", err)
@@ -155,24 +252,48 @@ func (s *Storage) SaveBrief(issue *model.Issue) error {
 		return err
 	}
 
-	return os.WriteFile(s.BriefPath(issue.ID), []byte(content), 0644)
+	if err := writeFileAtomic(s.BriefPath(issue.ID), []byte(content), 0644); err != nil {
+		return err
+	}
+	issue.Updated = time.Now()
+	s.touchIssue(issue.ID, issue.Updated)
+	return nil
+}
+
+// touchIssue records t as the issue's last update in index.yaml and stages it. A
+// no-op for issues not in the index yet.
+func (s *Storage) touchIssue(issueID string, t time.Time) {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return
+	}
+	entry := idx.GetIssue(issueID)
+	if entry == nil || !t.After(entry.Updated) {
+		return
+	}
+	entry.Updated = t
+	if s.SaveIndex(idx) == nil {
+		s.gitAdd(s.IndexPath())
+	}
 }
 
 // CreateIssue creates a new issue and saves it
-func (s *Storage) CreateIssue(title string, issueType model.IssueType, content string) (*model.Issue, error) {
+func (s *Storage) CreateIssue(title string, issueType model.IssueType, priority model.IssuePriority, content string) (*model.Issue, error) {
 	idx, err := s.LoadIndex()
 	if err != nil {
 		return nil, err
 	}
 
 	issue := &model.Issue{
-		ID:      idx.GetNextID(),
-		Title:   title,
-		Type:    issueType,
-		Status:  model.StatusOpen,
-		Created: time.Now(),
-		Content: content,
+		ID:       idx.GetNextID(),
+		Title:    title,
+		Type:     issueType,
+		Status:   model.StatusOpen,
+		Priority: model.ParsePriority(string(priority)),
+		Created:  time.Now(),
+		Content:  content,
 	}
+	issue.Updated = issue.Created
 
 	if err := s.SaveBrief(issue); err != nil {
 		return nil, err
@@ -184,6 +305,7 @@ func (s *Storage) CreateIssue(title string, issueType model.IssueType, content s
 	}
 
 	s.gitAdd(s.IndexPath(), s.BriefPath(issue.ID))
+	s.fireIssueCreated(issue)
 
 	return issue, nil
 }
@@ -199,10 +321,18 @@ func (s *Storage) UpdateIssueStatus(issueID string, status model.IssueStatus, re
 		return fmt.Errorf("issue not found: %s", issueID)
 	}
 
+	previous := issue.Status
 	issue.Status = status
 	if reason != "" {
 		issue.DiscardReason = reason
 	}
+	switch {
+	case status.IsClosed() && !previous.IsClosed():
+		issue.Closed = time.Now()
+	case !status.IsClosed():
+		issue.Closed = time.Time{}
+		issue.DiscardReason = ""
+	}
 
 	if err := s.SaveBrief(issue); err != nil {
 		return err
@@ -216,6 +346,7 @@ func (s *Storage) UpdateIssueStatus(issueID string, status model.IssueStatus, re
 
 	if idxIssue := idx.GetIssue(issueID); idxIssue != nil {
 		idxIssue.Status = status
+		idxIssue.Closed = issue.Closed
 		idx.UpdateIssue(idxIssue)
 		if err := s.SaveIndex(idx); err != nil {
 			return err
@@ -224,11 +355,91 @@ func (s *Storage) UpdateIssueStatus(issueID string, status model.IssueStatus, re
 
 	// Git add
 	s.gitAdd(s.IndexPath(), s.BriefPath(issueID))
+	s.fireStatusChanged(issueID, previous, status)
+
+	return nil
+}
+
+// ReopenIssue makes a closed issue active again, at the stage its artifacts
+// support: planned with a plan, analyzed with an analysis, open otherwise
+func (s *Storage) ReopenIssue(issueID string) (model.IssueStatus, error) {
+	status := model.StatusOpen
+	switch {
+	case s.PlanExists(issueID):
+		status = model.StatusPlanned
+	case s.HasAnalysis(issueID):
+		status = model.StatusAnalyzed
+	}
+	if err := s.UpdateIssueStatus(issueID, status, ""); err != nil {
+		return "", err
+	}
+	return status, nil
+}
+
+// UpdateIssuePriority updates issue priority in both brief.md and index.yaml
+func (s *Storage) UpdateIssuePriority(issueID string, priority model.IssuePriority) error {
+	issue, err := s.LoadBrief(issueID)
+	if err != nil {
+		return err
+	}
+	if issue == nil {
+		return fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	issue.Priority = priority
+	if err := s.SaveBrief(issue); err != nil {
+		return err
+	}
+
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return err
+	}
+	if idxIssue := idx.GetIssue(issueID); idxIssue != nil {
+		idxIssue.Priority = priority
+		idx.UpdateIssue(idxIssue)
+		if err := s.SaveIndex(idx); err != nil {
+			return err
+		}
+	}
+
+	s.gitAdd(s.IndexPath(), s.BriefPath(issueID))
+	return nil
+}
 
+// DeleteIssue permanently removes an issue's directory and its index entry
+func (s *Storage) DeleteIssue(issueID string) error {
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return err
+	}
+
+	dir := s.IssueDir(issueID)
+	_, statErr := os.Stat(dir)
+	inIndex := idx.RemoveIssue(issueID)
+	if os.IsNotExist(statErr) && !inIndex {
+		return fmt.Errorf("issue not found: %s", issueID)
+	}
+
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("removing issue dir: %w", err)
+	}
+	s.InvalidateIssue(issueID)
+
+	if inIndex {
+		if err := s.SaveIndex(idx); err != nil {
+			return err
+		}
+	}
+
+	s.gitRemove(dir)
+	s.gitAdd(s.IndexPath())
 	return nil
 }
 
-// SyncBriefToIndex syncs title and type from brief.md to index.yaml
+// SyncBriefToIndex syncs title, type, priority, labels and dependencies from brief.md
+// to index.yaml, and bumps the issue's update time if the brief was edited since.
+// Dependencies on missing issues are synced but reported as an error.
 func (s *Storage) SyncBriefToIndex(issueID string) error {
 	// Load brief.md to get current frontmatter values
 	brief, err := s.LoadBrief(issueID)
@@ -257,6 +468,24 @@ func (s *Storage) SyncBriefToIndex(issueID string) error {
 			idxIssue.Type = brief.Type
 			changed = true
 		}
+		if idxIssue.Priority != brief.Priority {
+			idxIssue.Priority = brief.Priority
+			changed = true
+		}
+		if !slices.Equal(idxIssue.Labels, brief.Labels) {
+			idxIssue.Labels = brief.Labels
+			changed = true
+		}
+		if !slices.Equal(idxIssue.Blocks, brief.Blocks) || !slices.Equal(idxIssue.BlockedBy, brief.BlockedBy) {
+			idxIssue.Blocks = brief.Blocks
+			idxIssue.BlockedBy = brief.BlockedBy
+			changed = true
+		}
+		// Edits made outside lfim, e.g. in $EDITOR
+		if edited := modTime(s.BriefPath(issueID)); edited.After(idxIssue.Updated) {
+			idxIssue.Updated = edited
+			changed = true
+		}
 
 		if changed {
 			idx.UpdateIssue(idxIssue)
@@ -266,36 +495,48 @@ func (s *Storage) SyncBriefToIndex(issueID string) error {
 			s.gitAdd(s.IndexPath())
 		}
 	}
+	if len(brief.Blocks) > 0 || len(brief.BlockedBy) > 0 {
+		return s.ValidateDependencies(brief)
+	}
 	return nil
 }
 
 // AnalysisExists checks if analysis.md exists for an issue
 func (s *Storage) AnalysisExists(issueID string) bool {
-	_, err := os.Stat(s.AnalysisPath(issueID))
-	return err == nil
+	return s.fileExists(s.AnalysisPath(issueID))
+}
+
+// HasAnalysis checks if an issue has an analysis in either form (analysis.md or analysis.json)
+func (s *Storage) HasAnalysis(issueID string) bool {
+	return s.AnalysisExists(issueID) || s.AnalysisJSONExists(issueID)
 }
 
 // PlanExists checks if plan.md exists for an issue
 func (s *Storage) PlanExists(issueID string) bool {
-	_, err := os.Stat(s.PlanPath(issueID))
-	return err == nil
+	return s.fileExists(s.PlanPath(issueID))
 }
 
 // SaveAnalysis saves analysis.md for an issue
 func (s *Storage) SaveAnalysis(issueID, content string) error {
 	path := s.AnalysisPath(issueID)
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	defer s.cache.invalidate(path)
+	if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
 		return err
 	}
+	s.touchIssue(issueID, time.Now())
+	s.fireAnalysisSaved(issueID)
 	return nil
 }
 
 // SavePlan saves plan.md for an issue
 func (s *Storage) SavePlan(issueID, content string) error {
 	path := s.PlanPath(issueID)
-	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+	defer s.cache.invalidate(path)
+	if err := writeFileAtomic(path, []byte(content), 0644); err != nil {
 		return err
 	}
+	s.touchIssue(issueID, time.Now())
+	s.firePlanSaved(issueID)
 	return nil
 }
 
@@ -305,7 +546,7 @@ func (s *Storage) LoadAnalysis(issueID string) (string, error) {
 	if os.IsNotExist(err) {
 		return "", nil
 	}
-	return string(data), err
+	return s.decodeText(s.AnalysisPath(issueID), data), err
 }
 
 // LoadPlan loads plan.md content for an issue
@@ -314,7 +555,7 @@ func (s *Storage) LoadPlan(issueID string) (string, error) {
 	if os.IsNotExist(err) {
 		return "", nil
 	}
-	return string(data), err
+	return s.decodeText(s.PlanPath(issueID), data), err
 }
 
 // Session management
@@ -322,12 +563,26 @@ func (s *Storage) SaveSessionID(issueID, sessionID string) error {
 	return os.WriteFile(s.SessionPath(issueID), []byte(sessionID), 0644)
 }
 
+// AppendClaudeLog records what a Claude call printed to stderr in the issue's
+// .claude.log, so warnings that don't fail the call aren't lost
+func (s *Storage) AppendClaudeLog(issueID, taskType, stderr string) error {
+	f, err := os.OpenFile(s.ClaudeLogPath(issueID), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(f, "--- %s %s\n%s\n", time.Now().Format(time.RFC3339), taskType, strings.TrimRight(stderr, "\n"))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
 func (s *Storage) LoadSessionID(issueID string) (string, error) {
 	data, err := os.ReadFile(s.SessionPath(issueID))
 	if os.IsNotExist(err) {
 		return "", nil
 	}
-	return string(data), err
+	return strings.TrimSpace(string(data)), err
 }
 
 func (s *Storage) ClearSessionID(issueID string) error {
@@ -347,7 +602,7 @@ func (s *Storage) GetAnalysisVersion(issueID string) int {
 		}
 		return 0
 	}
-	v, _ := strconv.Atoi(string(data))
+	v, _ := strconv.Atoi(strings.TrimSpace(string(data)))
 	return v
 }
 
@@ -370,6 +625,38 @@ func (s *Storage) SaveAnalysisVersioned(issueID, content string, version int) er
 	}
 
 	s.gitAdd(versionPath, trackerPath)
+	return s.pruneAnalysisVersions(issueID, version)
+}
+
+// pruneAnalysisVersions deletes the oldest analysis versions beyond
+// MaxAnalysisVersions, never touching current
+func (s *Storage) pruneAnalysisVersions(issueID string, current int) error {
+	if s.MaxAnalysisVersions <= 0 {
+		return nil
+	}
+	versions, err := s.ListAnalysisVersions(issueID)
+	if err != nil {
+		return err
+	}
+	keep := max(s.MaxAnalysisVersions, 2)
+	if len(versions) <= keep {
+		return nil
+	}
+
+	var removed []string
+	for _, v := range versions[:len(versions)-keep] {
+		if v == current {
+			continue
+		}
+		path := s.AnalysisVersionPath(issueID, v)
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		removed = append(removed, path)
+	}
+	if len(removed) > 0 {
+		s.gitRemove(removed...)
+	}
 	return nil
 }
 
@@ -378,7 +665,169 @@ func (s *Storage) LoadAnalysisVersion(issueID string, version int) (string, erro
 	if os.IsNotExist(err) {
 		return "", nil
 	}
-	return string(data), err
+	return s.decodeText(s.AnalysisVersionPath(issueID, version), data), err
+}
+
+// ListAnalysisVersions returns the saved analysis_vN.md version numbers in ascending order
+func (s *Storage) ListAnalysisVersions(issueID string) ([]int, error) {
+	entries, err := os.ReadDir(s.IssueDir(issueID))
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int
+	for _, entry := range entries {
+		var v int
+		if _, err := fmt.Sscanf(entry.Name(), "analysis_v%d.md", &v); err == nil && v > 0 {
+			versions = append(versions, v)
+		}
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// SaveAnalysisRevision saves content as the next analysis version. The current analysis
+// is archived first if it was never versioned, so the original is always recoverable.
+func (s *Storage) SaveAnalysisRevision(issueID, content string) (int, error) {
+	current := s.GetAnalysisVersion(issueID)
+
+	if current > 0 {
+		if _, err := os.Stat(s.AnalysisVersionPath(issueID, current)); os.IsNotExist(err) {
+			previous, err := s.LoadAnalysis(issueID)
+			if err != nil {
+				return 0, err
+			}
+			if err := os.WriteFile(s.AnalysisVersionPath(issueID, current), []byte(previous), 0644); err != nil {
+				return 0, err
+			}
+		}
+	} else if analysis, err := s.LoadAnalysisJSON(issueID); err == nil && analysis != nil {
+		// Structured analysis without analysis.md: archive its markdown form as v1
+		current = 1
+		if err := os.WriteFile(s.AnalysisVersionPath(issueID, current), []byte(analysis.ToMarkdown()), 0644); err != nil {
+			return 0, err
+		}
+	}
+
+	next := current + 1
+	if err := s.SaveAnalysisVersioned(issueID, content, next); err != nil {
+		return 0, err
+	}
+	if current > 0 {
+		// Stage the archived previous version along with the new one
+		s.gitAdd(s.AnalysisVersionPath(issueID, current))
+	}
+	return next, nil
+}
+
+// RestoreAnalysisVersion writes a previous version back as the current analysis,
+// recording it as a new version so history is never rewritten
+func (s *Storage) RestoreAnalysisVersion(issueID string, version int) (int, error) {
+	content, err := s.LoadAnalysisVersion(issueID, version)
+	if err != nil {
+		return 0, err
+	}
+	if content == "" {
+		return 0, fmt.Errorf("analysis version %d not found", version)
+	}
+	return s.SaveAnalysisRevision(issueID, content)
+}
+
+// GetPlanVersion returns the version currently in plan.md: 1 for a plan saved
+// before versioning, 0 without a plan
+func (s *Storage) GetPlanVersion(issueID string) int {
+	data, err := os.ReadFile(s.PlanVersionTrackerPath(issueID))
+	if err != nil {
+		if s.PlanExists(issueID) {
+			return 1
+		}
+		return 0
+	}
+	v, _ := strconv.Atoi(strings.TrimSpace(string(data)))
+	return v
+}
+
+func (s *Storage) SavePlanVersioned(issueID, content string, version int) error {
+	versionPath := s.PlanVersionPath(issueID, version)
+	if err := os.WriteFile(versionPath, []byte(content), 0644); err != nil {
+		return err
+	}
+
+	if err := s.SavePlan(issueID, content); err != nil {
+		return err
+	}
+
+	trackerPath := s.PlanVersionTrackerPath(issueID)
+	if err := os.WriteFile(trackerPath, []byte(strconv.Itoa(version)), 0644); err != nil {
+		return err
+	}
+
+	s.gitAdd(versionPath, trackerPath)
+	return nil
+}
+
+func (s *Storage) LoadPlanVersion(issueID string, version int) (string, error) {
+	data, err := os.ReadFile(s.PlanVersionPath(issueID, version))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return s.decodeText(s.PlanVersionPath(issueID, version), data), err
+}
+
+// ListPlanVersions returns the saved plan_vN.md version numbers in ascending order
+func (s *Storage) ListPlanVersions(issueID string) ([]int, error) {
+	entries, err := os.ReadDir(s.IssueDir(issueID))
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []int
+	for _, entry := range entries {
+		var v int
+		if _, err := fmt.Sscanf(entry.Name(), "plan_v%d.md", &v); err == nil && v > 0 {
+			versions = append(versions, v)
+		}
+	}
+	sort.Ints(versions)
+	return versions, nil
+}
+
+// SavePlanRevision saves content as the next plan version. Like analyses, a plan
+// that was never versioned is archived first so the original is recoverable.
+func (s *Storage) SavePlanRevision(issueID, content string) (int, error) {
+	current := s.GetPlanVersion(issueID)
+
+	if current > 0 {
+		if _, err := os.Stat(s.PlanVersionPath(issueID, current)); os.IsNotExist(err) {
+			previous, err := s.LoadPlan(issueID)
+			if err != nil {
+				return 0, err
+			}
+			if err := os.WriteFile(s.PlanVersionPath(issueID, current), []byte(previous), 0644); err != nil {
+				return 0, err
+			}
+			s.gitAdd(s.PlanVersionPath(issueID, current))
+		}
+	}
+
+	next := current + 1
+	if err := s.SavePlanVersioned(issueID, content, next); err != nil {
+		return 0, err
+	}
+	return next, nil
+}
+
+// RestorePlanVersion writes a previous version back as the current plan, recording
+// it as a new version
+func (s *Storage) RestorePlanVersion(issueID string, version int) (int, error) {
+	content, err := s.LoadPlanVersion(issueID, version)
+	if err != nil {
+		return 0, err
+	}
+	if content == "" {
+		return 0, fmt.Errorf("plan version %d not found", version)
+	}
+	return s.SavePlanRevision(issueID, content)
 }
 
 // Helper to convert map to Issue
@@ -389,30 +838,59 @@ func (s *Storage) issueFromMap(m map[string]interface{}) (*model.Issue, error) {
 	issue.Title = GetString(m, "title")
 	issue.Type = model.IssueType(GetString(m, "type"))
 	issue.Status = model.IssueStatus(GetString(m, "status"))
+	issue.Priority = model.ParsePriority(GetString(m, "priority"))
+	issue.Labels = GetStringSlice(m, "labels")
+	issue.Blocks = GetIDSlice(m, "blocks")
+	issue.BlockedBy = GetIDSlice(m, "blocked_by")
 
-	if dateStr := GetString(m, "created"); dateStr != "" {
-		issue.Created, _ = time.Parse("2006-01-02", dateStr)
+	issue.Created = GetTime(m, "created")
+	issue.Updated = GetTime(m, "updated")
+	if issue.Updated.IsZero() {
+		issue.Updated = issue.Created
 	}
+	issue.Closed = GetTime(m, "closed")
 
 	return issue, nil
 }
 
 // AnalysisJSONExists checks if analysis.json exists for an issue
 func (s *Storage) AnalysisJSONExists(issueID string) bool {
-	_, err := os.Stat(s.AnalysisJSONPath(issueID))
-	return err == nil
+	return s.fileExists(s.AnalysisJSONPath(issueID))
 }
 
-// SaveAnalysisJSON saves analysis.json for an issue
+// SaveAnalysisJSON saves analysis.json for an issue, and analysis.md as its
+// human-readable rendering
 func (s *Storage) SaveAnalysisJSON(issueID string, analysis *model.Analysis) error {
 	data, err := analysis.ToJSON()
 	if err != nil {
 		return fmt.Errorf("marshaling analysis: %w", err)
 	}
 	path := s.AnalysisJSONPath(issueID)
-	if err := os.WriteFile(path, data, 0644); err != nil {
+	defer s.cache.invalidate(path)
+	if err := writeFileAtomic(path, data, 0644); err != nil {
 		return fmt.Errorf("writing analysis.json: %w", err)
 	}
+	mdPath := s.AnalysisPath(issueID)
+	defer s.cache.invalidate(mdPath)
+	if err := writeFileAtomic(mdPath, []byte(analysis.ToMarkdown()), 0644); err != nil {
+		return fmt.Errorf("writing analysis.md: %w", err)
+	}
+	s.touchIssue(issueID, time.Now())
+	s.fireAnalysisSaved(issueID)
+	return nil
+}
+
+// removeAnalysisJSON deletes analysis.json for an issue, if present
+func (s *Storage) removeAnalysisJSON(issueID string) error {
+	path := s.AnalysisJSONPath(issueID)
+	defer s.cache.invalidate(path)
+	if err := os.Remove(path); err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("removing analysis.json: %w", err)
+	}
+	s.gitRemove(path)
 	return nil
 }
 
@@ -429,6 +907,50 @@ func (s *Storage) LoadAnalysisJSON(issueID string) (*model.Analysis, error) {
 	return model.ParseAnalysis(data)
 }
 
+// SaveAnalysisResult stores the raw output of an analyze call: as analysis.json when
+// it parses as a structured analysis, otherwise as analysis.md. An existing analysis
+// is kept as the previous version rather than overwritten. The session is kept
+// for later reviews and the issue is marked analyzed. Reports whether the structured
+// form was saved.
+func (s *Storage) SaveAnalysisResult(issueID, raw, sessionID string) (bool, error) {
+	analysis, err := ParseAnalysisFromRaw(raw)
+	if err != nil {
+		analysis = nil
+	}
+	previous := s.AnalysisExists(issueID) || s.AnalysisJSONExists(issueID)
+	if previous {
+		content := raw
+		if analysis != nil {
+			content = analysis.ToMarkdown()
+		}
+		if _, err := s.SaveAnalysisRevision(issueID, content); err != nil {
+			return false, err
+		}
+	}
+
+	structured := false
+	if analysis != nil {
+		structured = s.SaveAnalysisJSON(issueID, analysis) == nil
+	}
+	switch {
+	case structured:
+	case previous:
+		// analysis.json would shadow the new text analysis; its markdown form
+		// was archived with the previous version
+		if err := s.removeAnalysisJSON(issueID); err != nil {
+			return false, err
+		}
+	default:
+		if err := s.SaveAnalysis(issueID, raw); err != nil {
+			return false, err
+		}
+	}
+	if sessionID != "" {
+		_ = s.SaveSessionID(issueID, sessionID)
+	}
+	return structured, s.UpdateIssueStatus(issueID, model.StatusAnalyzed, "")
+}
+
 // UpdateSelectedOption updates the selected option in analysis.json
 func (s *Storage) UpdateSelectedOption(issueID, optionID string) error {
 	analysis, err := s.LoadAnalysisJSON(issueID)
@@ -446,6 +968,33 @@ func (s *Storage) UpdateSelectedOption(issueID, optionID string) error {
 	return s.SaveAnalysisJSON(issueID, analysis)
 }
 
+// AppendFeedback records review feedback with a timestamp in feedback.md
+func (s *Storage) AppendFeedback(issueID, feedback string) error {
+	path := s.FeedbackPath(issueID)
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("opening feedback.md: %w", err)
+	}
+	defer f.Close()
+
+	entry := fmt.Sprintf("## %s\n\n%s\n\n", time.Now().Format("2006-01-02 15:04"), strings.TrimSpace(feedback))
+	if _, err := f.WriteString(entry); err != nil {
+		return fmt.Errorf("writing feedback.md: %w", err)
+	}
+
+	s.gitAdd(path)
+	return nil
+}
+
+// LoadFeedback loads the feedback history for an issue
+func (s *Storage) LoadFeedback(issueID string) (string, error) {
+	data, err := os.ReadFile(s.FeedbackPath(issueID))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	return s.decodeText(s.FeedbackPath(issueID), data), err
+}
+
 // AppendChangeLog appends a change log entry to plan.md
 func (s *Storage) AppendChangeLog(issueID, changeLogEntry string) error {
 	planPath := s.PlanPath(issueID)
@@ -457,7 +1006,7 @@ func (s *Storage) AppendChangeLog(issueID, changeLogEntry string) error {
 	// Append the change log entry to the end of the file
 	updatedPlan := string(currentPlan) + "\n" + changeLogEntry
 
-	if err := os.WriteFile(planPath, []byte(updatedPlan), 0644); err != nil {
+	if err := writeFileAtomic(planPath, []byte(updatedPlan), 0644); err != nil {
 		return fmt.Errorf("writing plan.md: %w", err)
 	}
 
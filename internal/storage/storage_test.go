@@ -0,0 +1,245 @@
+package storage
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// saveAnalysisVersions creates an issue with analysis versions 1..n
+func saveAnalysisVersions(t *testing.T, s *Storage, n int) string {
+	t.Helper()
+	issue, err := s.CreateIssue("Versions", model.TypeBug, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for v := 1; v <= n; v++ {
+		if err := s.SaveAnalysisVersioned(issue.ID, fmt.Sprintf("## Summary\nv%d", v), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return issue.ID
+}
+
+func TestPruneAnalysisVersions(t *testing.T) {
+	tests := []struct {
+		max  int
+		want []int
+	}{
+		{0, []int{1, 2, 3, 4, 5}}, // unlimited
+		{3, []int{3, 4, 5}},
+		{5, []int{1, 2, 3, 4, 5}},
+		{10, []int{1, 2, 3, 4, 5}},
+		{1, []int{4, 5}}, // the previous version is always kept for diffs
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("max %d", tt.max), func(t *testing.T) {
+			s := newProject(t)
+			s.MaxAnalysisVersions = tt.max
+			id := saveAnalysisVersions(t, s, 5)
+
+			versions, err := s.ListAnalysisVersions(id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(versions, tt.want) {
+				t.Errorf("versions = %v, want %v", versions, tt.want)
+			}
+			if content, _ := s.LoadAnalysisVersion(id, 5); content != "## Summary\nv5" {
+				t.Errorf("latest version = %q", content)
+			}
+			if got := s.GetAnalysisVersion(id); got != 5 {
+				t.Errorf("current version = %d, want 5", got)
+			}
+		})
+	}
+}
+
+func TestPruneAnalysisVersionsKeepsCurrent(t *testing.T) {
+	s := newProject(t)
+	s.MaxAnalysisVersions = 2
+	id := saveAnalysisVersions(t, s, 4)
+
+	// Re-saving an old version number makes it current: it survives the prune
+	if err := s.SaveAnalysisVersioned(id, "## Summary\nback to v1", 1); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := s.ListAnalysisVersions(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 3, 4}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions = %v, want %v", versions, want)
+	}
+}
+
+func TestPruneAnalysisVersionsUnstagesRemoved(t *testing.T) {
+	s := newGitProject(t)
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	s.MaxAnalysisVersions = 2
+	id := saveAnalysisVersions(t, s, 3)
+
+	staged := git(t, s.ProjectRoot, "diff", "--cached", "--name-only")
+	if strings.Contains(staged, "analysis_v1.md") {
+		t.Errorf("pruned version still staged:\n%s", staged)
+	}
+	for _, v := range []int{2, 3} {
+		if name := fmt.Sprintf("issues/%s/analysis_v%d.md", id, v); !strings.Contains(staged, name) {
+			t.Errorf("%s not staged:\n%s", name, staged)
+		}
+	}
+}
+
+func TestSaveAnalysisResultKeepsPrevious(t *testing.T) {
+	s := newProject(t)
+	issue, err := s.CreateIssue("Again", model.TypeBug, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	structured := "```json\n{\"summary\": \"first\", \"options\": [{\"id\": \"A\", \"title\": \"Fix it\"}]}\n```"
+	if ok, err := s.SaveAnalysisResult(issue.ID, structured, ""); err != nil || !ok {
+		t.Fatalf("structured save = %v, %v", ok, err)
+	}
+
+	// A text result replaces the structured analysis, which is kept as v1
+	if ok, err := s.SaveAnalysisResult(issue.ID, "## Summary\nsecond", ""); err != nil || ok {
+		t.Fatalf("text save = %v, %v", ok, err)
+	}
+	if s.AnalysisJSONExists(issue.ID) {
+		t.Error("analysis.json left to shadow the text analysis")
+	}
+	if content, _ := s.LoadAnalysis(issue.ID); content != "## Summary\nsecond" {
+		t.Errorf("analysis.md = %q", content)
+	}
+	if content, _ := s.LoadAnalysisVersion(issue.ID, 1); !strings.Contains(content, "first") {
+		t.Errorf("v1 = %q, want the structured analysis", content)
+	}
+
+	if _, err := s.SaveAnalysisResult(issue.ID, "## Summary\nthird", ""); err != nil {
+		t.Fatal(err)
+	}
+	versions, err := s.ListAnalysisVersions(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1, 2, 3}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions = %v, want %v", versions, want)
+	}
+	if content, _ := s.LoadAnalysisVersion(issue.ID, 2); content != "## Summary\nsecond" {
+		t.Errorf("v2 = %q", content)
+	}
+}
+
+func TestSaveAnalysisRevisionFirstVersion(t *testing.T) {
+	s := newGitProject(t)
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	issue, err := s.CreateIssue("First", model.TypeBug, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	version, err := s.SaveAnalysisRevision(issue.ID, "## Summary\nv1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if version != 1 {
+		t.Errorf("version = %d, want 1", version)
+	}
+	versions, err := s.ListAnalysisVersions(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{1}; !reflect.DeepEqual(versions, want) {
+		t.Errorf("versions = %v, want %v", versions, want)
+	}
+	staged := git(t, s.ProjectRoot, "diff", "--cached", "--name-only")
+	if strings.Contains(staged, "analysis_v0.md") || !strings.Contains(staged, "analysis_v1.md") {
+		t.Errorf("staged:\n%s", staged)
+	}
+}
+
+// indexSummary lists the index as "id title status" lines
+func indexSummary(idx *model.IssueIndex) []string {
+	var lines []string
+	for _, issue := range idx.Issues {
+		lines = append(lines, fmt.Sprintf("%s %s %s", issue.ID, issue.Title, issue.Status))
+	}
+	return lines
+}
+
+func TestReadIndexDropsDuplicates(t *testing.T) {
+	s := newProject(t)
+	copyFixture(t, "index/duplicates.yaml", s.IndexPath())
+
+	idx, duplicates, err := s.readIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"0001 Login drops the session planned",
+		"0002 Dark mode open",
+		"0003 Split the config package open",
+	}
+	if got := indexSummary(idx); !reflect.DeepEqual(got, want) {
+		t.Errorf("index = %q, want the first entry of each ID %q", got, want)
+	}
+	if want := []string{"0001", "0003"}; !reflect.DeepEqual(duplicates, want) {
+		t.Errorf("duplicates = %q, want %q", duplicates, want)
+	}
+
+	// LoadIndex warns once per duplicated ID, however often it runs
+	for range 2 {
+		if _, err := s.LoadIndex(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	warnings := s.DrainWarnings()
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "0001 more than once") || !strings.Contains(warnings[1], "0003 more than once") {
+		t.Errorf("warnings = %q, want one for each of 0001 and 0003", warnings)
+	}
+}
+
+func TestRepairDropsDuplicateEntries(t *testing.T) {
+	s := newProject(t)
+	copyFixture(t, "index/duplicates.yaml", s.IndexPath())
+
+	problems, err := s.Diagnose()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var duplicates []Problem
+	for _, p := range problems {
+		if p.repair == repairDropDuplicate {
+			duplicates = append(duplicates, p)
+		}
+	}
+	if len(duplicates) != 2 {
+		t.Fatalf("diagnosed %d duplicate problems, want 2: %+v", len(duplicates), problems)
+	}
+	if _, err := s.Repair(duplicates); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, remaining, err := s.readIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(remaining) != 0 {
+		t.Errorf("duplicates left after repair: %q", remaining)
+	}
+	want := []string{
+		"0001 Login drops the session planned",
+		"0002 Dark mode open",
+		"0003 Split the config package open",
+	}
+	if got := indexSummary(idx); !reflect.DeepEqual(got, want) {
+		t.Errorf("repaired index = %q, want %q", got, want)
+	}
+}
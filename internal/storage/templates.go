@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// TemplatesDir returns the directory holding brief templates (issues/.templates).
+// A template named after an issue type (e.g. bug.md) is that type's default.
+func (s *Storage) TemplatesDir() string {
+	return filepath.Join(s.IssuesDir, ".templates")
+}
+
+// TemplatePath returns the path of a named template
+func (s *Storage) TemplatePath(name string) string {
+	return filepath.Join(s.TemplatesDir(), name+".md")
+}
+
+// ListTemplates returns the names of available templates, sorted
+func (s *Storage) ListTemplates() ([]string, error) {
+	entries, err := os.ReadDir(s.TemplatesDir())
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != ".md" {
+			continue
+		}
+		names = append(names, strings.TrimSuffix(entry.Name(), ".md"))
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// LoadTemplate returns a named template's body
+func (s *Storage) LoadTemplate(name string) (string, error) {
+	data, err := os.ReadFile(s.TemplatePath(name))
+	if os.IsNotExist(err) {
+		return "", fmt.Errorf("template not found: %s", name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(s.decodeText(s.TemplatePath(name), data)), nil
+}
+
+// TemplateBody returns the brief body for a new issue: the named template if given,
+// otherwise the type's default template, otherwise empty
+func (s *Storage) TemplateBody(name string, issueType model.IssueType) (string, error) {
+	if name != "" {
+		return s.LoadTemplate(name)
+	}
+	if _, err := os.Stat(s.TemplatePath(string(issueType))); err == nil {
+		return s.LoadTemplate(string(issueType))
+	}
+	return "", nil
+}
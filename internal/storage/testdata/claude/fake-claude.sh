@@ -0,0 +1,6 @@
+#!/bin/sh
+# Stand-in for the claude CLI: records the arguments of the latest call,
+# NUL-separated, in $FAKE_CLAUDE_ARGS and prints the canned response in
+# $FAKE_CLAUDE_RESPONSE
+printf '%s\0' "$@" > "$FAKE_CLAUDE_ARGS"
+cat "$FAKE_CLAUDE_RESPONSE"
@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// textWarnings records problems found while reading files (invalid UTF-8, duplicate
+// index entries) so each is reported only once
+type textWarnings struct {
+	mu      sync.Mutex
+	warned  map[string]bool
+	pending []string
+}
+
+// decodeText turns file bytes into display-safe text: invalid UTF-8 sequences are
+// replaced with U+FFFD (warning once per file) and line endings are normalized
+func (s *Storage) decodeText(path string, data []byte) string {
+	text := string(data)
+	if !utf8.Valid(data) {
+		text = strings.ToValidUTF8(text, string(utf8.RuneError))
+		s.warnInvalidUTF8(path)
+	}
+	return normalizeNewlines(text)
+}
+
+// warnInvalidUTF8 queues a warning for path unless it was already reported
+func (s *Storage) warnInvalidUTF8(path string) {
+	rel, err := filepath.Rel(s.IssuesDir, path)
+	if err != nil {
+		rel = path
+	}
+	s.warnOnce(path, fmt.Sprintf("%s contains invalid UTF-8; showing replacement characters", rel))
+}
+
+// warnOnce queues msg unless a warning was already queued under key
+func (s *Storage) warnOnce(key, msg string) {
+	w := &s.warnings
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.warned == nil {
+		w.warned = make(map[string]bool)
+	}
+	if w.warned[key] {
+		return
+	}
+	w.warned[key] = true
+	w.pending = append(w.pending, msg)
+}
+
+// DrainWarnings returns warnings queued since the last call
+func (s *Storage) DrainWarnings() []string {
+	w := &s.warnings
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	pending := w.pending
+	w.pending = nil
+	return pending
+}
@@ -0,0 +1,70 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+func TestDecodeText(t *testing.T) {
+	s := newProject(t)
+	path := s.AnalysisPath("0001")
+
+	if got := s.decodeText(path, []byte("caf\xc3\xa9\r\nok")); got != "café\nok" {
+		t.Errorf("valid text decoded as %q", got)
+	}
+	if warnings := s.DrainWarnings(); len(warnings) != 0 {
+		t.Errorf("valid text warned: %q", warnings)
+	}
+
+	if got, want := s.decodeText(path, []byte("bad \xff\xfe byte\r\n")), "bad � byte\n"; got != want {
+		t.Errorf("invalid text decoded as %q, want %q", got, want)
+	}
+	want := []string{"0001/analysis.md contains invalid UTF-8; showing replacement characters"}
+	if warnings := s.DrainWarnings(); !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %q, want %q", warnings, want)
+	}
+	if warnings := s.DrainWarnings(); len(warnings) != 0 {
+		t.Errorf("warnings not drained: %q", warnings)
+	}
+}
+
+func TestInvalidUTF8WarnsOncePerFile(t *testing.T) {
+	s := newProject(t)
+	issue, err := s.CreateIssue("Latin-1 notes", model.TypeBug, model.PriorityMedium, "body")
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeFile(t, s.AnalysisPath(issue.ID), "## Summary\nna\xefve\n")
+	writeFile(t, s.PlanPath(issue.ID), "## Plan Summary\n- r\xe9sum\xe9\n")
+
+	for range 3 {
+		if _, err := s.LoadAnalysis(issue.ID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	plan, err := s.LoadPlan(issue.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if plan != "## Plan Summary\n- r�sum�\n" {
+		t.Errorf("plan = %q", plan)
+	}
+
+	want := []string{
+		issue.ID + "/analysis.md contains invalid UTF-8; showing replacement characters",
+		issue.ID + "/plan.md contains invalid UTF-8; showing replacement characters",
+	}
+	if warnings := s.DrainWarnings(); !reflect.DeepEqual(warnings, want) {
+		t.Errorf("warnings = %q, want one per file %q", warnings, want)
+	}
+
+	// Already reported: later reads stay quiet
+	if _, err := s.LoadAnalysis(issue.ID); err != nil {
+		t.Fatal(err)
+	}
+	if warnings := s.DrainWarnings(); len(warnings) != 0 {
+		t.Errorf("warned again: %q", warnings)
+	}
+}
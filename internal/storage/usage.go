@@ -0,0 +1,66 @@
+package storage
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// UsagePath returns the path of an issue's accumulated Claude token usage
+func (s *Storage) UsagePath(issueID string) string {
+	return filepath.Join(s.IssueDir(issueID), ".usage.json")
+}
+
+// LoadUsage returns an issue's accumulated Claude usage; zero if none was recorded
+func (s *Storage) LoadUsage(issueID string) (model.Usage, error) {
+	var usage model.Usage
+	data, err := os.ReadFile(s.UsagePath(issueID))
+	if os.IsNotExist(err) {
+		return usage, nil
+	}
+	if err != nil {
+		return usage, err
+	}
+	if err := json.Unmarshal(data, &usage); err != nil {
+		return model.Usage{}, fmt.Errorf("parsing %s: %w", s.UsagePath(issueID), err)
+	}
+	return usage, nil
+}
+
+// AddUsage adds one Claude call's usage to the issue's totals in .usage.json
+func (s *Storage) AddUsage(issueID string, usage model.Usage) error {
+	total, err := s.LoadUsage(issueID)
+	if err != nil {
+		return err
+	}
+	total.Add(usage)
+
+	data, err := json.MarshalIndent(total, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.IssueDir(issueID), 0755); err != nil {
+		return err
+	}
+	return writeFileAtomic(s.UsagePath(issueID), append(data, '\n'), 0644)
+}
+
+// TotalUsage sums the recorded Claude usage of every indexed issue
+func (s *Storage) TotalUsage() (model.Usage, error) {
+	var total model.Usage
+	idx, err := s.LoadIndex()
+	if err != nil {
+		return total, err
+	}
+	for _, issue := range idx.Issues {
+		usage, err := s.LoadUsage(issue.ID)
+		if err != nil {
+			return total, err
+		}
+		total.Add(usage)
+	}
+	return total, nil
+}
@@ -1,22 +1,28 @@
 package tui
 
 import (
+	"context"
 	"fmt"
+	"hash/crc32"
 	"os"
-	"os/exec"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/mattn/go-runewidth"
 
 	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/config"
 	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/notify"
 	"github.com/lunit-heesungyang/issue-manager/internal/storage"
 	"github.com/lunit-heesungyang/issue-manager/internal/ui"
 )
@@ -26,23 +32,111 @@ type FilterMode int
 
 const (
 	FilterActive FilterMode = iota
+	FilterImplemented
 	FilterAll
 	FilterClosed
+	FilterInconsistent
+
+	filterModeCount
 )
 
 func (f FilterMode) String() string {
 	switch f {
 	case FilterActive:
 		return "Active"
+	case FilterImplemented:
+		return "Implemented"
 	case FilterAll:
 		return "All"
 	case FilterClosed:
 		return "Closed"
+	case FilterInconsistent:
+		return "Inconsistent"
 	}
 	return ""
 }
 
+// feedbackInputHeight is the number of lines in the multi-line feedback editor
+const feedbackInputHeight = 5
+
+// feedbackHistoryLines is how many lines of past feedback are shown above the editor
+const feedbackHistoryLines = 4
+
+// LayoutMode represents which panes are visible in the main view
+type LayoutMode int
+
+const (
+	LayoutSplit LayoutMode = iota
+	LayoutListOnly
+	LayoutPreviewOnly
+)
+
+func (l LayoutMode) String() string {
+	switch l {
+	case LayoutSplit:
+		return "Split"
+	case LayoutListOnly:
+		return "List only"
+	case LayoutPreviewOnly:
+		return "Preview only"
+	}
+	return ""
+}
+
+// Split ratio bounds for the list/preview layout
+const (
+	defaultSplitRatio = 0.5
+	minSplitRatio     = 0.2
+	maxSplitRatio     = 0.8
+	splitRatioStep    = 0.05
+)
+
 // AppState represents the current UI state
+//
+// The keys that lead into each state (from StateNormal unless noted):
+//
+//	n                                 → StateInput (new issue title)
+//	Enter on the title                → StateTypeSelect
+//	f/b/r in StateTypeSelect          → StateTemplateSelect, when the project has templates
+//	R                                 → StateReviewPreview, or StateOptionSelect for a structured analysis
+//	P                                 → StatePlanPreview
+//	f in a review preview             → StateInput (review or plan feedback)
+//	s in a review preview             → StateCompare
+//	V in a review preview             → StateVersions (Enter views a version, b blames it)
+//	d in a review preview or versions → StateVersionDiff
+//	/, m, u                           → StateInput (search, merge, change reason)
+//	d, D, i; a/p when rerunning       → StateConfirm
+//	c                                 → StateCommitGenerating → StateCommitConfirm
+//	                                    (StateConfirm in close.mode issue-only)
+//	o, g, t, ?                        → StateDetail, StateGitDiff, StateChecklist, StateHelp
+//
+// Esc always backs out exactly one level:
+//
+//	StateInput (new issue title)      → StateNormal
+//	StateTypeSelect                   → StateInput (title kept)
+//	StateTemplateSelect               → StateTypeSelect
+//	StateInput (review feedback)      → StateReviewPreview
+//	StateInput (plan feedback)        → StatePlanPreview
+//	StateInput (add option)           → StateOptionSelect
+//	StateInput (change reason/merge)  → StateNormal
+//	StateInput (search)               → StateNormal (active search kept)
+//	StateInput (bulk count)           → StateNormal (marks kept)
+//	StateNormal with active search    → clears the search
+//	StateNormal with marked issues    → clears the marks
+//	StateReviewPreview / PlanPreview  → clears an active find query, then StateNormal
+//	StateOptionSelect                 → StateNormal
+//	StateConfirm                      → StateNormal
+//	StateCommitGenerating             → StateNormal (result is discarded)
+//	StateCommitConfirm                → StateNormal
+//	StateDetail                       → StateNormal
+//	StateGitDiff                      → StateNormal
+//	StateCompare                      → the preview it was opened from
+//	StateVersions (viewing a version) → StateVersions (list)
+//	StateVersions (blame)             → StateVersions (list)
+//	StateVersions (list)              → the preview it was opened from
+//	StateVersionDiff                  → StateVersions or the preview it was opened from
+//	StateChecklist                    → StateNormal
+//	StateHelp                         → StateNormal (any key)
 type AppState int
 
 const (
@@ -55,6 +149,14 @@ const (
 	StateCommitConfirm
 	StateCommitGenerating
 	StateOptionSelect
+	StateDetail
+	StateCompare
+	StateVersions
+	StateTemplateSelect
+	StateChecklist
+	StateHelp
+	StateGitDiff
+	StateVersionDiff
 )
 
 // InputMode represents what input is being collected
@@ -67,31 +169,56 @@ const (
 	InputPlanReview
 	InputAddOption
 	InputChangeReason
+	InputMerge
+	InputSearch
+	InputBulkConfirm
 )
 
 // Model is the main Bubble Tea model
 type Model struct {
 	// Core dependencies
-	storage *storage.Storage
-	claude  *claude.Client
-	keys    KeyMap
-	styles  Styles
+	storage     *storage.Storage
+	projectName string // shown in the header to tell terminals apart
+	claude      *claude.Client
+	ctx         context.Context // parent of every Claude call; see Cleanup
+	cancel      context.CancelFunc
+	keys        KeyMap
+	styles      Styles
+	config      *config.Config
+	notifier    *notify.Notifier // nil when no hooks are configured
+	prefs       *config.State
 
 	// Window dimensions
-	width  int
-	height int
+	width      int
+	height     int
+	splitRatio float64    // fraction of width given to the issue list
+	layout     LayoutMode // which panes are visible
+	inline     bool       // running without the alternate screen
+	ascii      bool       // terminal can't render Unicode: draw ASCII glyphs
+	editor     string     // --editor override of $EDITOR
+	recent     recentIssues
 
 	// Issue list state
-	issues     []*model.Issue
-	selected   int
-	filterMode FilterMode
+	issues       []*model.Issue
+	selected     int
+	filterMode   FilterMode
+	sortMode     SortMode
+	labelFilter  string                    // only issues with this label; "" shows all
+	labels       []string                  // every label in the index, for cycling labelFilter
+	blocked      map[string]bool           // listed issues with a blocker that isn't closed
+	selectNext   string                    // issue to select once the list reloads, e.g. one just created
+	statusCounts map[model.IssueStatus]int // whole-index breakdown for the header
+	taskProgress map[string]taskProgress   // checklist progress by issue ID
+	summaries    map[string]string         // list subtitles by issue ID
+	marked       map[string]bool           // issue IDs marked for bulk analyze/discard/delete
 
 	// UI state
 	state     AppState
 	statusMsg string
 
 	// Processing state
-	processing     map[string]string // issueID -> taskType
+	processing     map[string]string             // issueID -> taskType
+	cancels        map[string]context.CancelFunc // issueID -> cancels its running task
 	processingLock *sync.Mutex
 	spinnerFrame   int
 
@@ -99,35 +226,64 @@ type Model struct {
 	resultChan chan claude.TaskResult
 
 	// Sub-components
-	textInput textinput.Model
-	viewport  viewport.Model
+	textInput     textinput.Model
+	feedbackInput textarea.Model // multi-line review/plan feedback
+	viewport      viewport.Model
 
 	// Confirm state
 	confirmMsg    string
 	confirmAction func()
+	// confirmCmd runs on confirm instead of confirmAction, reporting back with an
+	// actionDoneMsg: a closure can't set the status on the Model it captured
+	confirmCmd tea.Cmd
+
+	// Bulk discard/delete/analyze waiting for its y/n confirm or typed count
+	pendingBulk *bulkAction
+
+	// Pending "press again" override for acting on a closed issue (action:issueID)
+	closedOverride string
 
 	// Input state
 	inputPrompt string
 	inputMode   InputMode
 
 	// Type select state
-	pendingTitle string
+	pendingTitle    string
+	pendingPriority model.IssuePriority // chosen in the type select overlay
+
+	// Named template picker shown after type select
+	templates templatePicker
 
 	// Review state
 	reviewAnalysis string
 	reviewPlan     string
+	detailContent  string // brief or diff shown in the detail and git diff overlays
+
+	// Markdown rendering for the preview panel and review overlays
+	markdown    *markdownCache
+	rawMarkdown bool // show markdown source instead of rendering it
+
+	// Past feedback shown while composing new feedback
+	feedbackHistory []string
 
 	// Horizontal scroll state
-	hOffset      int // horizontal scroll offset
-	maxLineWidth int // max line width in current content
+	hOffset      int  // horizontal scroll offset
+	wrapPreview  bool // wrap long lines in the review overlays instead of panning
+	maxLineWidth int  // max line width in current content
 
 	// Commit state
 	pendingCommitMsg  string
 	pendingCloseIssue *model.Issue
+	// Set in plan close mode: the commit is limited to these paths
+	pendingCommitPaths []string
+	// Plan-vs-diff comparison shown with the commit message; nil without a usable plan
+	pendingPlanReport *storage.PlanDiffReport
 
 	// Retry confirmation state
 	pendingRetryIssue *model.Issue
 	pendingImplement  bool
+	// Issue awaiting confirmation of an issue-only close (close.mode issue-only)
+	pendingIssueOnlyClose *model.Issue
 
 	// List scroll state
 	listVOffset      int // vertical scroll offset for issue list
@@ -141,30 +297,92 @@ type Model struct {
 	detailViewport     viewport.Model  // viewport for option detail section
 	detailHOffset      int             // horizontal scroll offset for detail panel
 	detailMaxLineWidth int             // max line width in detail content
+
+	// Side-by-side analysis/plan view state
+	compare compareState
+
+	// Analysis version picker state
+	versions versionState
+
+	// Version diff state
+	versionDiff versionDiffState
+
+	// Checklist overlay state
+	checklist checklistState
+
+	// Full-text search narrowing the issue list
+	search listSearch
+
+	// Find-in-document state for the review previews
+	find findState
+
+	// Review preview scroll positions, keyed by "analysis/<id>" or "plan/<id>"
+	readPositions map[string]readingPosition
 }
 
-// New creates a new TUI model
-func New(projectPath string) Model {
-	s := storage.New(projectPath)
+// New creates a new TUI model. issuesDir overrides the default "issues" directory.
+func New(projectPath, issuesDir string, cfg *config.Config) Model {
+	s := storage.New(projectPath, issuesDir)
+	s.MaxAnalysisVersions = cfg.MaxAnalysisVersions
 	_ = s.EnsureIssuesDir()
 
 	ti := textinput.New()
 	ti.CharLimit = 200
 	ti.Width = 50
 
+	ta := textarea.New()
+	ta.ShowLineNumbers = false
+	ta.Placeholder = "One or more points of feedback..."
+	ta.SetHeight(feedbackInputHeight)
+
 	vp := viewport.New(40, 20)
 	summaryVp := viewport.New(40, 10)
 	detailVp := viewport.New(40, 20)
 
+	// Resolved root, so claude picks up the project's own settings
+	claudeClient := claude.New(s.ProjectRoot)
+	claudeClient.Executable = cfg.Claude.Command
+	claudeClient.ExtraArgs = cfg.Claude.ExtraArgs
+	claudeClient.ReadOnly = cfg.Claude.ReadOnly
+	claudeClient.Timeout = cfg.Claude.Timeout
+	claudeClient.SetMaxConcurrent(cfg.Claude.MaxConcurrent)
+
+	// Cancelled by Cleanup so Claude calls still running at exit are killed
+	ctx, cancel := context.WithCancel(context.Background())
+
+	notifier := notify.Attach(s, cfg.Hooks)
+
+	prefs := config.LoadState()
+	splitRatio := prefs.SplitRatio
+	if splitRatio < minSplitRatio || splitRatio > maxSplitRatio {
+		splitRatio = defaultSplitRatio
+	}
+
 	return Model{
 		storage:         s,
-		claude:          claude.New(projectPath),
+		projectName:     s.ProjectName(),
+		claude:          claudeClient,
+		ctx:             ctx,
+		cancel:          cancel,
 		keys:            DefaultKeyMap(),
 		styles:          DefaultStyles(),
+		config:          cfg,
+		notifier:        notifier,
+		prefs:           prefs,
+		wrapPreview:     prefs.WrapPreview,
+		recent:          newRecentIssues(prefs.Recent[recentKeyFor(s)]),
+		splitRatio:      splitRatio,
 		processing:      make(map[string]string),
+		cancels:         make(map[string]context.CancelFunc),
+		readPositions:   make(map[string]readingPosition),
+		taskProgress:    make(map[string]taskProgress),
+		summaries:       make(map[string]string),
+		marked:          make(map[string]bool),
+		markdown:        newMarkdownCache(false),
 		processingLock:  &sync.Mutex{},
 		resultChan:      make(chan claude.TaskResult, 10),
 		textInput:       ti,
+		feedbackInput:   ta,
 		viewport:        vp,
 		summaryViewport: summaryVp,
 		detailViewport:  detailVp,
@@ -172,6 +390,19 @@ func New(projectPath string) Model {
 	}
 }
 
+// WithInline configures the model for running without the alternate screen
+func (m Model) WithInline() Model {
+	m.inline = true
+	return m
+}
+
+// WithASCII renders ASCII stand-ins for icons, borders and the spinner
+func (m Model) WithASCII() Model {
+	m.ascii = true
+	m.markdown = newMarkdownCache(true)
+	return m
+}
+
 // Init implements tea.Model
 func (m Model) Init() tea.Cmd {
 	return tea.Batch(
@@ -201,14 +432,44 @@ func (m Model) listenForResults() tea.Cmd {
 }
 
 // Refresh issues from storage
-type issuesLoadedMsg []*model.Issue
+type issuesLoadedMsg struct {
+	issues       []*model.Issue
+	statusCounts map[model.IssueStatus]int // across the whole index, not just the filter
+	taskProgress map[string]taskProgress   // checklist progress of the listed issues
+	summaries    map[string]string         // list subtitles of the listed issues
+	labels       []string                  // every label in the index
+	blocked      map[string]bool           // listed issues with an active blocker
+}
 
 // Request to refresh issues (triggers refreshIssues command)
-type refreshRequestMsg struct{}
+type refreshRequestMsg struct {
+	editorErr error // set when the refresh follows an editor that exited with an error
+}
+
+// issueRefreshedMsg carries the re-read state of a single issue
+type issueRefreshedMsg struct {
+	issueID   string
+	artifacts *storage.IssueWithArtifacts
+	err       error
+}
 
 // syncAfterEditMsg triggers brief-to-index sync after editor closes
 type syncAfterEditMsg struct {
 	issueID string
+	created bool  // the issue was just created for this edit
+	err     error // the editor's exit error, e.g. from vim's :cq
+}
+
+// issueOnlyClosedMsg reports an issue-only close and its chore commit
+type issueOnlyClosedMsg struct {
+	issueID   string
+	committed bool
+	err       error // the status update failed; nothing was committed
+}
+
+// actionDoneMsg reports a confirmed action's outcome as the status message
+type actionDoneMsg struct {
+	status string
 }
 
 // implementCompletedMsg triggers status update after implementation completes
@@ -216,9 +477,22 @@ type implementCompletedMsg struct {
 	issueID string
 }
 
+// refreshIssue re-syncs and re-reads a single issue without reloading the whole list
+func (m Model) refreshIssue(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		m.storage.InvalidateIssue(issueID)
+		if err := m.storage.SyncBriefToIndex(issueID); err != nil {
+			return issueRefreshedMsg{issueID: issueID, err: err}
+		}
+		artifacts, err := m.storage.GetIssueWithArtifacts(issueID)
+		return issueRefreshedMsg{issueID: issueID, artifacts: artifacts, err: err}
+	}
+}
+
 func (m Model) refreshIssues() tea.Cmd {
 	return func() tea.Msg {
-		idx, err := m.storage.LoadIndex()
+		m.storage.InvalidateCache()
+		idx, err := m.storage.EnsureIndex()
 		if err != nil {
 			return nil
 		}
@@ -226,12 +500,10 @@ func (m Model) refreshIssues() tea.Cmd {
 		var filtered []*model.Issue
 		switch m.filterMode {
 		case FilterActive:
-			filtered = idx.FilterByStatus(
-				model.StatusOpen,
-				model.StatusAnalyzed,
-				model.StatusPlanned,
-				model.StatusImplemented,
-			)
+			filtered = idx.GetActiveIssues()
+		case FilterImplemented:
+			// Done but not yet closed: the final review stage
+			filtered = idx.FilterByStatus(model.StatusImplemented)
 		case FilterAll:
 			filtered = idx.Issues
 		case FilterClosed:
@@ -239,15 +511,64 @@ func (m Model) refreshIssues() tea.Cmd {
 				model.StatusClosed,
 				model.StatusInvalid,
 			)
+		case FilterInconsistent:
+			// Artifacts that disagree with the status, e.g. after an interrupted run
+			for _, issue := range idx.Issues {
+				if m.artifactsMismatch(issue) {
+					filtered = append(filtered, issue)
+				}
+			}
+		}
+		filtered = filterByLabel(filtered, m.labelFilter)
+		filtered = m.search.filter(filtered)
+		filtered = sortIssues(filtered, m.sortMode)
+		return issuesLoadedMsg{
+			issues:       filtered,
+			statusCounts: idx.CountByStatus(),
+			taskProgress: m.loadTaskProgress(filtered),
+			summaries:    m.loadSummaries(filtered),
+			labels:       idx.Labels(),
+			blocked:      blockedIssues(idx, filtered),
+		}
+	}
+}
+
+// blockedIssues returns which of issues have a blocker that isn't closed yet
+func blockedIssues(idx *model.IssueIndex, issues []*model.Issue) map[string]bool {
+	blocked := make(map[string]bool)
+	for _, issue := range issues {
+		if idx.IsBlocked(issue.ID) {
+			blocked[issue.ID] = true
 		}
-		return issuesLoadedMsg(filtered)
 	}
+	return blocked
+}
+
+// artifactsMismatch reports whether an active issue's analysis and plan on disk
+// don't match its status
+func (m Model) artifactsMismatch(issue *model.Issue) bool {
+	hasAnalysis := m.storage.HasAnalysis(issue.ID)
+	hasPlan := m.storage.PlanExists(issue.ID)
+	switch issue.Status {
+	case model.StatusOpen:
+		return hasAnalysis || hasPlan
+	case model.StatusAnalyzed:
+		return !hasAnalysis || hasPlan
+	case model.StatusPlanned, model.StatusImplemented:
+		return !hasAnalysis || !hasPlan
+	}
+	return false
 }
 
 // Update implements tea.Model
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
+	// Surface malformed-file warnings from earlier reads (each file is reported once)
+	if warnings := m.storage.DrainWarnings(); len(warnings) > 0 {
+		m.statusMsg = warnings[0]
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
@@ -255,22 +576,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
+		if m.inline {
+			// Keep one line free: filling the whole terminal outside the
+			// alternate screen scrolls each frame's first line into scrollback
+			m.height--
+		}
 		m.viewport.Width = msg.Width / 2
 		m.viewport.Height = msg.Height - 4
 		// Validate scroll offsets after resize
-		listVisibleHeight := m.height - 3
-		if listVisibleHeight < 1 {
-			listVisibleHeight = 1
-		}
-		m.ensureSelectedVisible(listVisibleHeight)
-		// Validate horizontal scroll offset
-		listWidth := m.width/2 - 2
-		maxHOffset := m.listMaxLineWidth - listWidth
-		if maxHOffset < 0 {
-			maxHOffset = 0
-		}
-		if m.listHOffset > maxHOffset {
-			m.listHOffset = maxHOffset
+		m.ensureSelectedVisible(m.listVisibleItems())
+		m.clampListHOffset()
+		if m.state == StateCompare {
+			m.sizeCompareViewports()
 		}
 		return m, nil
 
@@ -279,16 +596,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.tickCmd())
 
 	case issuesLoadedMsg:
-		m.issues = msg
-		if m.selected >= len(m.issues) {
-			m.selected = max(0, len(m.issues)-1)
+		selectedID := m.selectedID()
+		if m.selectNext != "" {
+			selectedID, m.selectNext = m.selectNext, ""
 		}
+		m.issues = msg.issues
+		m.reselect(selectedID)
+		m.statusCounts = msg.statusCounts
+		m.taskProgress = msg.taskProgress
+		m.summaries = msg.summaries
+		m.labels = msg.labels
+		m.blocked = msg.blocked
+		m.pruneMarks()
 		// Validate vertical scroll offset
-		listVisibleHeight := m.height - 3
-		if listVisibleHeight < 1 {
-			listVisibleHeight = 1
-		}
-		m.ensureSelectedVisible(listVisibleHeight)
+		m.ensureSelectedVisible(m.listVisibleItems())
 		// Calculate max line width for horizontal scrolling
 		m.calculateListMaxLineWidth()
 		return m, nil
@@ -299,11 +620,72 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		cmds = append(cmds, m.refreshIssues())
 
 	case refreshRequestMsg:
+		if msg.editorErr != nil {
+			m.statusMsg = fmt.Sprintf("Editor exited with an error: %v", msg.editorErr)
+		}
 		return m, m.refreshIssues()
 
+	case issueRefreshedMsg:
+		if msg.err != nil {
+			m.statusMsg = fmt.Sprintf("Refresh %s failed: %v", msg.issueID, msg.err)
+			return m, nil
+		}
+		for _, issue := range m.issues {
+			if issue.ID == msg.issueID {
+				issue.Title = msg.artifacts.Issue.Title
+				issue.Type = msg.artifacts.Issue.Type
+			}
+		}
+		for id, progress := range m.loadTaskProgress([]*model.Issue{msg.artifacts.Issue}) {
+			m.taskProgress[id] = progress
+		}
+		if !m.config.UI.CompactList {
+			m.summaries[msg.issueID] = issueSummary(msg.artifacts.Issue, msg.artifacts.AnalysisJSON)
+		}
+		m.calculateListMaxLineWidth()
+		m.statusMsg = fmt.Sprintf("Refreshed %s (analysis: %s, plan: %s)",
+			msg.issueID, yesNo(msg.artifacts.HasAnalysis()), yesNo(msg.artifacts.HasPlan()))
+		return m, nil
+
 	case syncAfterEditMsg:
+		if msg.err != nil && !msg.created {
+			// A failed exit (e.g. vim's :cq) means the edit was abandoned
+			m.statusMsg = fmt.Sprintf("Editor exited with an error (%v) - %s not synced", msg.err, msg.issueID)
+			return m, m.refreshIssues()
+		}
 		// Sync brief.md changes to index.yaml
-		_ = m.storage.SyncBriefToIndex(msg.issueID)
+		if err := m.storage.SyncBriefToIndex(msg.issueID); err != nil {
+			m.statusMsg = fmt.Sprintf("Warning: %v", err)
+		}
+		if msg.created {
+			// Land on the new issue wherever the sort and filter put it
+			m.selectNext = msg.issueID
+		}
+		if msg.err != nil {
+			// Aborting the editor on a new issue likely means abandoning it
+			issueID := msg.issueID
+			m.state = StateConfirm
+			m.confirmMsg = fmt.Sprintf("Editor exited with an error. Delete the new issue %s?", issueID)
+			m.confirmCmd = m.deleteIssueCmd(issueID)
+		}
+		return m, m.refreshIssues()
+
+	case searchResultMsg:
+		return m.applySearchResults(msg)
+
+	case issueOnlyClosedMsg:
+		switch {
+		case msg.err != nil:
+			m.statusMsg = fmt.Sprintf("Close %s failed: %v", msg.issueID, msg.err)
+		case msg.committed:
+			m.statusMsg = fmt.Sprintf("Closed %s (issue files committed)", msg.issueID)
+		default:
+			m.statusMsg = fmt.Sprintf("Closed %s (commit failed)", msg.issueID)
+		}
+		return m, m.refreshIssues()
+
+	case actionDoneMsg:
+		m.statusMsg = msg.status
 		return m, m.refreshIssues()
 
 	case implementCompletedMsg:
@@ -331,25 +713,52 @@ func (m Model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case StateCommitConfirm:
 		return m.handleCommitConfirmKey(msg)
 	case StateCommitGenerating:
-		// Ignore key input while generating
+		// Only Esc is accepted while generating; the late result is discarded
+		if key.Matches(msg, m.keys.Escape) {
+			return m.cancelCommit("Commit message generation cancelled"), nil
+		}
 		return m, nil
 	case StateOptionSelect:
 		return m.handleOptionSelectKey(msg)
+	case StateDetail:
+		return m.handleDetailKey(msg)
+	case StateGitDiff:
+		return m.handleGitDiffKey(msg)
+	case StateCompare:
+		return m.handleCompareKey(msg)
+	case StateVersions:
+		return m.handleVersionsKey(msg)
+	case StateVersionDiff:
+		return m.handleVersionDiffKey(msg)
+	case StateTemplateSelect:
+		return m.handleTemplateSelectKey(msg)
+	case StateChecklist:
+		return m.handleChecklistKey(msg)
+	case StateHelp:
+		// Any key dismisses the help overlay
+		m.state = StateNormal
+		return m, nil
 	default:
 		return m.handleNormalKey(msg)
 	}
 }
 
 func (m Model) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
-	// Calculate visible height for list (same as in View)
-	listVisibleHeight := m.height - 3
-	if listVisibleHeight < 1 {
-		listVisibleHeight = 1
-	}
+	// Calculate how many issues the list shows (same as in View)
+	listVisibleHeight := m.listVisibleItems()
 
 	// Horizontal scroll step size
 	const hScrollStep = 5
 
+	// A closed-issue override only applies to the very next key press
+	armed := m.closedOverride
+	m.closedOverride = ""
+
+	// Anything but moving through the list counts as viewing the selected issue
+	if !key.Matches(msg, m.keys.Up, m.keys.Down, m.keys.Recent) {
+		m.recent.visit(m.selectedID())
+	}
+
 	switch {
 	case key.Matches(msg, m.keys.Quit):
 		return m, tea.Quit
@@ -358,6 +767,9 @@ func (m Model) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.selected > 0 {
 			m.selected--
 			m.ensureSelectedVisible(listVisibleHeight)
+		} else if m.config.UI.WrapNavigation && len(m.issues) > 1 {
+			m.selected = len(m.issues) - 1
+			m.ensureSelectedVisible(listVisibleHeight)
 		}
 		return m, nil
 
@@ -365,9 +777,15 @@ func (m Model) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		if m.selected < len(m.issues)-1 {
 			m.selected++
 			m.ensureSelectedVisible(listVisibleHeight)
+		} else if m.config.UI.WrapNavigation && len(m.issues) > 1 {
+			m.selected = 0
+			m.ensureSelectedVisible(listVisibleHeight)
 		}
 		return m, nil
 
+	case key.Matches(msg, m.keys.Recent):
+		return m.jumpRecent()
+
 	case key.Matches(msg, m.keys.New):
 		return m.startNewIssue()
 
@@ -380,16 +798,37 @@ func (m Model) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	case key.Matches(msg, m.keys.Discard):
 		return m.confirmDiscard()
 
+	case key.Matches(msg, m.keys.Delete):
+		return m.confirmDelete()
+
+	case key.Matches(msg, m.keys.Merge):
+		return m.startMerge()
+
 	case key.Matches(msg, m.keys.Analyze):
+		if len(m.marked) > 0 {
+			return m.confirmBulkAnalyze()
+		}
+		if !m.allowOnClosed("analyze it", "a", armed) {
+			return m, nil
+		}
 		return m.analyzeIssue()
 
 	case key.Matches(msg, m.keys.Plan):
+		if !m.allowOnClosed("plan it", "p", armed) {
+			return m, nil
+		}
 		return m.planIssue()
 
 	case key.Matches(msg, m.keys.Review):
+		if !m.allowOnClosed("review it", "R", armed) {
+			return m, nil
+		}
 		return m.reviewIssue()
 
 	case key.Matches(msg, m.keys.PlanReview):
+		if !m.allowOnClosed("review its plan", "P", armed) {
+			return m, nil
+		}
 		return m.planReviewIssue()
 
 	case key.Matches(msg, m.keys.Implement):
@@ -402,36 +841,96 @@ func (m Model) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.statusMsg = "Refreshed"
 		return m, m.refreshIssues()
 
+	case key.Matches(msg, m.keys.RefreshOne):
+		issue := m.getSelectedIssue()
+		if issue == nil {
+			m.statusMsg = "No issue selected"
+			return m, nil
+		}
+		return m, m.refreshIssue(issue.ID)
+
 	case key.Matches(msg, m.keys.Filter):
-		m.filterMode = (m.filterMode + 1) % 3
+		m.filterMode = (m.filterMode + 1) % filterModeCount
 		m.listVOffset = 0 // Reset vertical scroll on filter change
 		m.listHOffset = 0 // Reset horizontal scroll on filter change
 		m.statusMsg = fmt.Sprintf("Filter: %s", m.filterMode)
 		return m, m.refreshIssues()
+
+	case key.Matches(msg, m.keys.Sort):
+		m.sortMode = (m.sortMode + 1) % sortModeCount
+		m.statusMsg = fmt.Sprintf("Sort: %s", m.sortMode)
+		return m, m.refreshIssues()
+
+	case key.Matches(msg, m.keys.LabelFilter):
+		return m.cycleLabelFilter()
+
+	case key.Matches(msg, m.keys.ShrinkList):
+		return m.adjustSplitRatio(-splitRatioStep), nil
+
+	case key.Matches(msg, m.keys.GrowList):
+		return m.adjustSplitRatio(splitRatioStep), nil
+
+	case key.Matches(msg, m.keys.Layout):
+		m.layout = (m.layout + 1) % 3
+		m.clampListHOffset()
+		m.statusMsg = fmt.Sprintf("Layout: %s", m.layout)
+		return m, nil
+
+	case key.Matches(msg, m.keys.Detail):
+		return m.openDetail()
+
+	case key.Matches(msg, m.keys.GitDiff):
+		return m.openGitDiff()
+
+	case key.Matches(msg, m.keys.Branch):
+		return m.checkoutIssueBranch()
+
+	case key.Matches(msg, m.keys.CopyPath):
+		return m.copyIssuePath()
+
+	case key.Matches(msg, m.keys.Search):
+		return m.startSearch()
+
+	case key.Matches(msg, m.keys.Cancel):
+		return m.cancelTask()
+
+	case key.Matches(msg, m.keys.Reopen):
+		return m.reopenLastClosed()
+
+	case key.Matches(msg, m.keys.PriorityUp):
+		return m.bumpPriority(1)
+
+	case key.Matches(msg, m.keys.PriorityDown):
+		return m.bumpPriority(-1)
+
+	case key.Matches(msg, m.keys.Help):
+		m.state = StateHelp
+		return m, nil
+
+	case key.Matches(msg, m.keys.Escape) && m.search.active():
+		return m.clearSearch()
+
+	case key.Matches(msg, m.keys.Escape) && len(m.marked) > 0:
+		return m.clearMarks()
+
+	case key.Matches(msg, m.keys.Mark):
+		return m.toggleMark()
+
+	case key.Matches(msg, m.keys.RawMarkdown):
+		return m.toggleRawMarkdown()
+
+	case key.Matches(msg, m.keys.Checklist):
+		return m.openChecklist()
 	}
 
 	// Handle horizontal scroll with left/right arrow keys
-	listWidth := m.width/2 - 2
+	listWidth := m.listPanelWidth() - 2
 	switch msg.String() {
 	case "left":
-		if m.listHOffset > 0 {
-			m.listHOffset -= hScrollStep
-			if m.listHOffset < 0 {
-				m.listHOffset = 0
-			}
-		}
+		m.listHOffset = panOffset(m.listHOffset, -hScrollStep, m.listMaxLineWidth, listWidth)
 		return m, nil
 	case "right":
-		maxOffset := m.listMaxLineWidth - listWidth
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-		if m.listHOffset < maxOffset {
-			m.listHOffset += hScrollStep
-			if m.listHOffset > maxOffset {
-				m.listHOffset = maxOffset
-			}
-		}
+		m.listHOffset = panOffset(m.listHOffset, hScrollStep, m.listMaxLineWidth, listWidth)
 		return m, nil
 	}
 
@@ -439,6 +938,10 @@ func (m Model) handleNormalKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 }
 
 func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.inputMode == InputReview || m.inputMode == InputPlanReview {
+		return m.handleFeedbackKey(msg)
+	}
+
 	switch msg.Type {
 	case tea.KeyEnter:
 		value := m.textInput.Value()
@@ -453,31 +956,10 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 			m.pendingTitle = value
+			m.pendingPriority = model.PriorityMedium
 			m.state = StateTypeSelect
 			m.inputMode = InputNone
 			return m, nil
-		case InputReview:
-			if value == "" {
-				// Go back to review preview
-				m.state = StateReviewPreview
-				m.inputMode = InputNone
-				return m, nil
-			}
-			m.state = StateNormal
-			m.inputMode = InputNone
-			m.reviewAnalysis = ""
-			return m.executeReview(value)
-		case InputPlanReview:
-			if value == "" {
-				// Go back to plan preview
-				m.state = StatePlanPreview
-				m.inputMode = InputNone
-				return m, nil
-			}
-			m.state = StateNormal
-			m.inputMode = InputNone
-			m.reviewPlan = ""
-			return m.executePlanReview(value)
 		case InputAddOption:
 			if value == "" {
 				// Go back to option select
@@ -492,26 +974,25 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.state = StateNormal
 			m.inputMode = InputNone
 			return m.executeUpdateChangeLog(value)
-		default:
+		case InputMerge:
 			m.state = StateNormal
-			return m, nil
-		}
-
-	case tea.KeyEsc:
-		if m.inputMode == InputReview {
-			// Go back to review preview
-			m.state = StateReviewPreview
 			m.inputMode = InputNone
-			m.textInput.Reset()
-			return m, nil
-		}
-		if m.inputMode == InputPlanReview {
-			// Go back to plan preview
-			m.state = StatePlanPreview
+			return m.executeMerge(strings.TrimSpace(value))
+		case InputSearch:
+			m.state = StateNormal
 			m.inputMode = InputNone
-			m.textInput.Reset()
+			return m.runSearch(value)
+		case InputBulkConfirm:
+			m.state = StateNormal
+			m.inputMode = InputNone
+			return m.executeBulkConfirm(value)
+		default:
+			m.state = StateNormal
 			return m, nil
 		}
+	}
+
+	if key.Matches(msg, m.keys.Escape) {
 		if m.inputMode == InputAddOption {
 			// Go back to option select
 			m.state = StateOptionSelect
@@ -528,6 +1009,7 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		}
 		m.state = StateNormal
 		m.inputMode = InputNone
+		m.pendingBulk = nil
 		m.textInput.Reset()
 		m.statusMsg = "Cancelled"
 		return m, nil
@@ -538,6 +1020,73 @@ func (m Model) handleInputKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, cmd
 }
 
+// handleFeedbackKey drives the multi-line feedback editor used by review and plan review.
+// Enter inserts a newline; Ctrl+D submits; Esc goes back to the preview.
+func (m Model) handleFeedbackKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	backToPreview := func(m Model) Model {
+		if m.inputMode == InputReview {
+			m.state = StateReviewPreview
+		} else {
+			m.state = StatePlanPreview
+		}
+		m.inputMode = InputNone
+		m.feedbackInput.Reset()
+		m.feedbackInput.Blur()
+		m.sizeOverlayViewport()
+		return m
+	}
+
+	if key.Matches(msg, m.keys.Escape) {
+		return backToPreview(m), nil
+	}
+
+	if key.Matches(msg, m.keys.SubmitFeedback) {
+		value := strings.TrimSpace(m.feedbackInput.Value())
+		if value == "" {
+			return backToPreview(m), nil
+		}
+
+		mode := m.inputMode
+		m.feedbackInput.Reset()
+		m.feedbackInput.Blur()
+		m.state = StateNormal
+		m.inputMode = InputNone
+		if mode == InputReview {
+			m.reviewAnalysis = ""
+			return m.executeReview(value)
+		}
+		m.reviewPlan = ""
+		return m.executePlanReview(value)
+	}
+
+	var cmd tea.Cmd
+	m.feedbackInput, cmd = m.feedbackInput.Update(msg)
+	return m, cmd
+}
+
+// startFeedbackInput switches from a review preview to the multi-line feedback editor
+func (m Model) startFeedbackInput(mode InputMode, prompt string) (Model, tea.Cmd) {
+	m.state = StateInput
+	m.inputMode = mode
+	m.inputPrompt = prompt
+
+	// Show the most recent past feedback so it isn't repeated
+	m.feedbackHistory = nil
+	if issue := m.getSelectedIssue(); issue != nil {
+		history, _ := m.storage.LoadFeedback(issue.ID)
+		m.feedbackHistory = recentFeedbackLines(history, feedbackHistoryLines)
+	}
+
+	// Shrink the preview so the editor (and history) fit below it
+	m.viewport.Height -= feedbackInputHeight + len(m.feedbackHistory)
+	if m.viewport.Height < 3 {
+		m.viewport.Height = 3
+	}
+	m.feedbackInput.SetWidth(m.viewport.Width)
+	m.feedbackInput.Reset()
+	return m, m.feedbackInput.Focus()
+}
+
 func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch {
 	case key.Matches(msg, m.keys.Yes):
@@ -550,6 +1099,19 @@ func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.pendingImplement = false
 			return m.executeImplementFor(issue)
 		}
+		if issue := m.pendingIssueOnlyClose; issue != nil {
+			m.pendingIssueOnlyClose = nil
+			return m, m.closeIssueFilesOnly(issue)
+		}
+		if cmd := m.confirmCmd; cmd != nil {
+			m.confirmCmd = nil
+			return m, cmd
+		}
+		if action := m.pendingBulk; action != nil {
+			m.pendingBulk = nil
+			m.runBulk(*action)
+			return m, m.refreshIssues()
+		}
 
 		// Handle other confirm actions
 		if m.confirmAction != nil {
@@ -562,6 +1124,9 @@ func (m Model) handleConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		m.state = StateNormal
 		m.pendingRetryIssue = nil
 		m.pendingImplement = false
+		m.pendingIssueOnlyClose = nil
+		m.confirmCmd = nil
+		m.pendingBulk = nil
 		m.statusMsg = "Cancelled"
 		return m, nil
 	}
@@ -577,9 +1142,25 @@ func (m Model) handleTypeSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.createIssue(model.TypeBug)
 	case "r", "3":
 		return m.createIssue(model.TypeRefactor)
-	case "esc":
-		m.state = StateNormal
-		m.statusMsg = "Cancelled"
+	}
+
+	switch {
+	case key.Matches(msg, m.keys.PriorityUp):
+		m.pendingPriority = m.pendingPriority.Bump(1)
+		return m, nil
+	case key.Matches(msg, m.keys.PriorityDown):
+		m.pendingPriority = m.pendingPriority.Bump(-1)
+		return m, nil
+	}
+
+	if key.Matches(msg, m.keys.Escape) {
+		// Go back to title input, keeping what was typed
+		m.state = StateInput
+		m.inputMode = InputNewIssue
+		m.inputPrompt = "Title: "
+		m.textInput.SetValue(m.pendingTitle)
+		m.textInput.Focus()
+		return m, textinput.Blink
 	}
 	return m, nil
 }
@@ -588,6 +1169,17 @@ func (m Model) handleReviewPreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Horizontal scroll step size
 	const hScrollStep = 10
 
+	if m.find.typing {
+		return m.handleFindKey(msg)
+	}
+	if key.Matches(msg, m.keys.Escape) {
+		if m.find.query != "" {
+			m.clearFind()
+			return m, nil
+		}
+		return m.closeReviewPreview(), nil
+	}
+
 	switch msg.String() {
 	// Vertical scroll keys
 	case "up", "k":
@@ -611,58 +1203,108 @@ func (m Model) handleReviewPreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Horizontal scroll keys
 	case "left", "h":
-		if m.hOffset > 0 {
-			m.hOffset -= hScrollStep
-			if m.hOffset < 0 {
-				m.hOffset = 0
-			}
-			// Update viewport content with new offset
-			yOffset := m.viewport.YOffset
-			m.viewport.SetContent(applyHorizontalOffset(m.reviewAnalysis, m.hOffset, m.viewport.Width))
-			m.viewport.SetYOffset(yOffset)
-		}
+		m.panPreview(-hScrollStep)
 		return m, nil
 	case "right", "l":
-		maxOffset := m.maxLineWidth - m.viewport.Width
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-		if m.hOffset < maxOffset {
-			m.hOffset += hScrollStep
-			if m.hOffset > maxOffset {
-				m.hOffset = maxOffset
-			}
-			// Update viewport content with new offset
-			yOffset := m.viewport.YOffset
-			m.viewport.SetContent(applyHorizontalOffset(m.reviewAnalysis, m.hOffset, m.viewport.Width))
-			m.viewport.SetYOffset(yOffset)
-		}
+		m.panPreview(hScrollStep)
 		return m, nil
 
 	// Action keys
 	case "e":
 		return m.editAnalysis()
+	case "p":
+		// Jump straight to planning the reviewed issue
+		return m.closeReviewPreview().planIssue()
+	case "s":
+		return m.openCompare()
+	case "V":
+		return m.openVersions("analysis")
+	case "d":
+		return m.openVersionDiff("analysis", 0)
+	case "M":
+		return m.toggleRawMarkdown()
+	case "w":
+		return m.toggleWrapPreview()
+	case "b":
+		return m.openInBrowser("analysis", m.reviewAnalysis)
+	case "/":
+		m.find = findState{typing: true}
+		return m, nil
+	case "n":
+		m.jumpToMatch(1)
+		return m, nil
+	case "N":
+		m.jumpToMatch(-1)
+		return m, nil
 	case "f":
 		// Switch to feedback input mode
-		m.state = StateInput
-		m.inputMode = InputReview
-		m.inputPrompt = "Feedback: "
-		m.textInput.Focus()
-		return m, textinput.Blink
-	case "c", "esc":
-		m.state = StateNormal
-		m.reviewAnalysis = ""
-		m.hOffset = 0
-		return m, nil
+		return m.startFeedbackInput(InputReview, "Feedback: ")
+	case "c":
+		return m.closeReviewPreview(), nil
 	}
 
 	return m, nil
 }
 
+// closeReviewPreview leaves the analysis review overlay
+func (m Model) closeReviewPreview() Model {
+	m.saveReadingPosition("analysis", m.reviewAnalysis)
+	m.state = StateNormal
+	m.reviewAnalysis = ""
+	m.hOffset = 0
+	m.find = findState{}
+	return m
+}
+
+// readingPosition remembers where a document was left in a review preview
+type readingPosition struct {
+	yOffset  int
+	hOffset  int
+	checksum uint32 // the position is dropped once the document changes
+}
+
+// saveReadingPosition records the preview scroll position for the selected issue's document
+func (m Model) saveReadingPosition(doc, content string) {
+	issue := m.getSelectedIssue()
+	if issue == nil || content == "" {
+		return
+	}
+	m.readPositions[doc+"/"+issue.ID] = readingPosition{
+		yOffset:  m.viewport.YOffset,
+		hOffset:  m.hOffset,
+		checksum: crc32.ChecksumIEEE([]byte(content)),
+	}
+}
+
+// restoreReadingPosition resumes a document where it was left, unless it changed since
+func (m *Model) restoreReadingPosition(doc, issueID, content string) {
+	pos, ok := m.readPositions[doc+"/"+issueID]
+	if !ok || pos.checksum != crc32.ChecksumIEEE([]byte(content)) {
+		delete(m.readPositions, doc+"/"+issueID)
+		return
+	}
+	m.hOffset = pos.hOffset
+	if m.hOffset > 0 {
+		m.setPreviewContent(m.previewText())
+	}
+	m.viewport.SetYOffset(pos.yOffset)
+}
+
 func (m Model) handlePlanPreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Horizontal scroll step size
 	const hScrollStep = 10
 
+	if m.find.typing {
+		return m.handleFindKey(msg)
+	}
+	if key.Matches(msg, m.keys.Escape) {
+		if m.find.query != "" {
+			m.clearFind()
+			return m, nil
+		}
+		return m.closePlanPreview(), nil
+	}
+
 	switch msg.String() {
 	// Vertical scroll keys
 	case "up", "k":
@@ -686,55 +1328,64 @@ func (m Model) handlePlanPreviewKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Horizontal scroll keys
 	case "left", "h":
-		if m.hOffset > 0 {
-			m.hOffset -= hScrollStep
-			if m.hOffset < 0 {
-				m.hOffset = 0
-			}
-			// Update viewport content with new offset
-			yOffset := m.viewport.YOffset
-			m.viewport.SetContent(applyHorizontalOffset(m.reviewPlan, m.hOffset, m.viewport.Width))
-			m.viewport.SetYOffset(yOffset)
-		}
+		m.panPreview(-hScrollStep)
 		return m, nil
 	case "right", "l":
-		maxOffset := m.maxLineWidth - m.viewport.Width
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-		if m.hOffset < maxOffset {
-			m.hOffset += hScrollStep
-			if m.hOffset > maxOffset {
-				m.hOffset = maxOffset
-			}
-			// Update viewport content with new offset
-			yOffset := m.viewport.YOffset
-			m.viewport.SetContent(applyHorizontalOffset(m.reviewPlan, m.hOffset, m.viewport.Width))
-			m.viewport.SetYOffset(yOffset)
-		}
+		m.panPreview(hScrollStep)
 		return m, nil
 
 	// Action keys
 	case "e":
 		return m.editPlan()
+	case "i":
+		// Jump straight to implementing the reviewed plan
+		return m.closePlanPreview().implementIssue()
+	case "s":
+		return m.openCompare()
+	case "V":
+		return m.openVersions("plan")
+	case "d":
+		return m.openVersionDiff("plan", 0)
+	case "M":
+		return m.toggleRawMarkdown()
+	case "w":
+		return m.toggleWrapPreview()
+	case "b":
+		return m.openInBrowser("plan", m.reviewPlan)
+	case "/":
+		m.find = findState{typing: true}
+		return m, nil
+	case "n":
+		m.jumpToMatch(1)
+		return m, nil
+	case "N":
+		m.jumpToMatch(-1)
+		return m, nil
 	case "f":
 		// Switch to feedback input mode
-		m.state = StateInput
-		m.inputMode = InputPlanReview
-		m.inputPrompt = "Plan Feedback: "
-		m.textInput.Focus()
-		return m, textinput.Blink
-	case "c", "esc":
-		m.state = StateNormal
-		m.reviewPlan = ""
-		m.hOffset = 0
-		return m, nil
+		return m.startFeedbackInput(InputPlanReview, "Plan Feedback: ")
+	case "c":
+		return m.closePlanPreview(), nil
 	}
 
 	return m, nil
 }
 
+// closePlanPreview leaves the plan review overlay
+func (m Model) closePlanPreview() Model {
+	m.saveReadingPosition("plan", m.reviewPlan)
+	m.state = StateNormal
+	m.reviewPlan = ""
+	m.hOffset = 0
+	m.find = findState{}
+	return m
+}
+
 func (m Model) handleCommitConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
+		return m.cancelCommit("Cancelled"), nil
+	}
+
 	switch msg.String() {
 	// Scroll keys
 	case "up", "k":
@@ -755,7 +1406,14 @@ func (m Model) handleCommitConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		issue := m.pendingCloseIssue
 		_ = m.storage.UpdateIssueStatus(issue.ID, model.StatusClosed, "")
 
-		if m.storage.HasStagedChanges() {
+		if m.pendingCommitPaths != nil {
+			m.storage.StageIssueFiles(issue.ID)
+			if success, _ := m.storage.CommitPaths(m.pendingCommitMsg, m.pendingCommitPaths); success {
+				m.statusMsg = fmt.Sprintf("Closed & committed %s (%d plan files)", issue.ID, len(m.pendingCommitPaths))
+			} else {
+				m.statusMsg = fmt.Sprintf("Closed %s (commit failed)", issue.ID)
+			}
+		} else if m.storage.HasStagedChanges() {
 			success, _ := m.storage.GitCommit(m.pendingCommitMsg)
 			if success {
 				m.statusMsg = fmt.Sprintf("Closed & committed %s", issue.ID)
@@ -766,80 +1424,233 @@ func (m Model) handleCommitConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			m.statusMsg = fmt.Sprintf("Closed %s (no changes to commit)", issue.ID)
 		}
 
-		m.state = StateNormal
-		m.pendingCloseIssue = nil
-		m.pendingCommitMsg = ""
+		m = m.clearPendingCommit()
 		return m, m.refreshIssues()
 
 	// Cancel
-	case "n", "esc":
+	case "n":
+		return m.cancelCommit("Cancelled"), nil
+	}
+
+	return m, nil
+}
+
+// cancelCommit abandons the pending close/commit flow
+func (m Model) cancelCommit(status string) Model {
+	m = m.clearPendingCommit()
+	m.statusMsg = status
+	return m
+}
+
+// clearPendingCommit ends the close/commit flow and drops its state
+func (m Model) clearPendingCommit() Model {
+	m.state = StateNormal
+	m.pendingCloseIssue = nil
+	m.pendingCommitMsg = ""
+	m.pendingCommitPaths = nil
+	m.pendingPlanReport = nil
+	return m
+}
+
+func (m Model) handleDetailKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
 		m.state = StateNormal
-		m.pendingCloseIssue = nil
-		m.pendingCommitMsg = ""
-		m.statusMsg = "Cancelled"
+		m.detailContent = ""
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		m.viewport.LineUp(1)
+	case "down", "j":
+		m.viewport.LineDown(1)
+	case "pgup", "ctrl+u":
+		m.viewport.HalfViewUp()
+	case "pgdown", "ctrl+d":
+		m.viewport.HalfViewDown()
+	case "home", "g":
+		m.viewport.GotoTop()
+	case "end", "G":
+		m.viewport.GotoBottom()
+	case "e":
+		m.state = StateNormal
+		m.detailContent = ""
+		return m.editIssue()
+	case "o", "q":
+		m.state = StateNormal
+		m.detailContent = ""
+	}
+	return m, nil
+}
+
+// finishPartialAnalysis drops the streamed partial output once the result is saved,
+// or points to it when the run failed
+func (m *Model) finishPartialAnalysis(result claude.TaskResult) {
+	if result.Success {
+		m.storage.ClearPartialAnalysis(result.IssueID)
+		return
+	}
+	if m.storage.HasPartialAnalysis(result.IssueID) {
+		m.statusMsg += fmt.Sprintf(" - partial output kept in %s", m.storage.PartialAnalysisPath(result.IssueID))
+	}
+}
+
+// rejectDroppedSections checks a review result against the current document, which
+// the review prompts ask to keep the section headers of. If sections vanished, the
+// result is kept aside in .review.rejected.md instead of becoming a new version.
+func (m *Model) rejectDroppedSections(result claude.TaskResult, document string, load func(string) (string, error)) bool {
+	original, err := load(result.IssueID)
+	if err != nil {
+		return false
+	}
+	missing := model.MissingSections(original, result.Result)
+	if len(missing) == 0 {
+		return false
+	}
+	if result.SessionID != "" {
+		_ = m.storage.SaveSessionID(result.IssueID, result.SessionID)
+	}
+	m.statusMsg = fmt.Sprintf("Warning: review of %s dropped %s; kept the previous %s", result.IssueID, strings.Join(missing, ", "), document)
+	if err := m.storage.SaveRejectedReview(result.IssueID, result.Result); err == nil {
+		m.statusMsg += fmt.Sprintf(" (result in %s)", m.storage.RejectedReviewPath(result.IssueID))
+	}
+	return true
+}
+
+// runStreaming starts an analysis-producing task whose output is streamed to the
+// issue's partial file, replacing leftovers from an earlier interrupted run
+func (m *Model) runStreaming(issueID, taskType, prompt, sessionID string) {
+	m.storage.ClearPartialAnalysis(issueID)
+	m.claude.RunAsyncStreaming(m.taskContext(issueID), issueID, taskType, prompt, m.config.Claude.ModelFor(taskType), sessionID, m.storage.PartialAnalysisPath(issueID), m.resultChan)
+}
+
+// taskContext returns a context for a Claude task on issueID that cancelTask can
+// cancel without touching other issues' tasks
+func (m *Model) taskContext(issueID string) context.Context {
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.processingLock.Lock()
+	m.cancels[issueID] = cancel
+	m.processingLock.Unlock()
+	return ctx
+}
+
+// cancelTask stops the selected issue's running Claude task. Its result still
+// arrives, marked Cancelled, and is discarded by handleResult.
+func (m Model) cancelTask() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
 		return m, nil
 	}
 
+	m.processingLock.Lock()
+	taskType, ok := m.processing[issue.ID]
+	cancel := m.cancels[issue.ID]
+	delete(m.processing, issue.ID)
+	delete(m.cancels, issue.ID)
+	m.processingLock.Unlock()
+
+	if !ok {
+		m.statusMsg = fmt.Sprintf("%s has no running task", issue.ID)
+		return m, nil
+	}
+	if cancel != nil {
+		cancel()
+	}
+	m.statusMsg = fmt.Sprintf("Cancelled %s for %s", taskType, issue.ID)
 	return m, nil
 }
 
 func (m *Model) handleResult(result claude.TaskResult) {
+	if result.Cancelled {
+		// cancelTask already cleared the issue, which may be running a newer task by now
+		if result.TaskType == "analyze" || result.TaskType == "review" {
+			m.finishPartialAnalysis(result)
+		}
+		return
+	}
+
 	m.processingLock.Lock()
+	if m.processing[result.IssueID] == result.TaskType {
+		if cancel := m.cancels[result.IssueID]; cancel != nil {
+			cancel()
+			delete(m.cancels, result.IssueID)
+		}
+	}
 	delete(m.processing, result.IssueID)
 	m.processingLock.Unlock()
 
+	if result.TaskType == "analyze" || result.TaskType == "review" {
+		defer m.finishPartialAnalysis(result)
+	}
+	if result.TimedOut {
+		defer func() { m.statusMsg += ": " + result.Result }()
+	}
+	if !result.Usage.IsZero() && result.IssueID != "" {
+		_ = m.storage.AddUsage(result.IssueID, result.Usage)
+	}
+	if result.Stderr != "" && result.IssueID != "" {
+		if err := m.storage.AppendClaudeLog(result.IssueID, result.TaskType, result.Stderr); err == nil && result.Success {
+			defer func() { m.statusMsg += " (Claude warnings in .claude.log)" }()
+		}
+	}
+
 	switch result.TaskType {
 	case "analyze":
 		if result.Success {
-			// Try to parse as JSON first
-			analysis, err := storage.ParseAnalysisFromRaw(result.Result)
-			if err == nil && analysis != nil {
-				// Save as JSON
-				if saveErr := m.storage.SaveAnalysisJSON(result.IssueID, analysis); saveErr == nil {
-					if result.SessionID != "" {
-						_ = m.storage.SaveSessionID(result.IssueID, result.SessionID)
-					}
-					_ = m.storage.UpdateIssueStatus(result.IssueID, model.StatusAnalyzed, "")
-					m.statusMsg = fmt.Sprintf("Analyzed %s - press R to review options", result.IssueID)
-					return
-				}
-			}
-
-			// Fallback to markdown if JSON parsing fails
-			_ = m.storage.SaveAnalysis(result.IssueID, result.Result)
-			if result.SessionID != "" {
-				_ = m.storage.SaveSessionID(result.IssueID, result.SessionID)
+			// Structured JSON when it parses, markdown otherwise
+			structured, _ := m.storage.SaveAnalysisResult(result.IssueID, result.Result, result.SessionID)
+			if structured {
+				m.statusMsg = fmt.Sprintf("Analyzed %s - press R to review options", result.IssueID)
+			} else {
+				m.statusMsg = fmt.Sprintf("Analyzed %s (text mode)", result.IssueID)
 			}
-			_ = m.storage.UpdateIssueStatus(result.IssueID, model.StatusAnalyzed, "")
-			m.statusMsg = fmt.Sprintf("Analyzed %s (text mode)", result.IssueID)
 		} else {
 			m.statusMsg = fmt.Sprintf("Analyze %s failed", result.IssueID)
 		}
 	case "plan":
 		if result.Success {
-			_ = m.storage.SavePlan(result.IssueID, result.Result)
+			version, err := m.storage.SavePlanRevision(result.IssueID, result.Result)
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("Plan %s: failed to save plan: %v", result.IssueID, err)
+				return
+			}
 			_ = m.storage.UpdateIssueStatus(result.IssueID, model.StatusPlanned, "")
-			m.statusMsg = fmt.Sprintf("Planned %s", result.IssueID)
+			m.statusMsg = fmt.Sprintf("Planned %s (plan v%d)", result.IssueID, version)
 		} else {
 			m.statusMsg = fmt.Sprintf("Plan %s failed", result.IssueID)
 		}
 	case "review":
 		if result.Success {
-			_ = m.storage.SaveAnalysis(result.IssueID, result.Result)
+			if m.rejectDroppedSections(result, "analysis", m.storage.LoadAnalysis) {
+				return
+			}
+			version, err := m.storage.SaveAnalysisRevision(result.IssueID, result.Result)
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("Review %s: failed to save analysis: %v", result.IssueID, err)
+				return
+			}
 			if result.SessionID != "" {
 				_ = m.storage.SaveSessionID(result.IssueID, result.SessionID)
 			}
-			m.statusMsg = fmt.Sprintf("Reviewed %s", result.IssueID)
+			m.statusMsg = fmt.Sprintf("Reviewed %s (analysis v%d)", result.IssueID, version)
 		} else {
 			m.statusMsg = fmt.Sprintf("Review %s failed", result.IssueID)
 		}
 	case "plan-review":
 		if result.Success {
-			_ = m.storage.SavePlan(result.IssueID, result.Result)
+			if m.rejectDroppedSections(result, "plan", m.storage.LoadPlan) {
+				return
+			}
+			version, err := m.storage.SavePlanRevision(result.IssueID, result.Result)
+			if err != nil {
+				m.statusMsg = fmt.Sprintf("Plan review %s: failed to save plan: %v", result.IssueID, err)
+				return
+			}
 			if result.SessionID != "" {
 				_ = m.storage.SaveSessionID(result.IssueID, result.SessionID)
 			}
-			m.statusMsg = fmt.Sprintf("Plan reviewed %s", result.IssueID)
+			m.statusMsg = fmt.Sprintf("Plan reviewed %s (plan v%d)", result.IssueID, version)
 		} else {
 			m.statusMsg = fmt.Sprintf("Plan review %s failed", result.IssueID)
 		}
@@ -866,14 +1677,16 @@ func (m *Model) handleResult(result claude.TaskResult) {
 			m.statusMsg = fmt.Sprintf("Add option failed for %s", result.IssueID)
 		}
 	case "commit":
+		// Ignore results for a close flow the user already cancelled
+		if m.state != StateCommitGenerating || m.pendingCloseIssue == nil || m.pendingCloseIssue.ID != result.IssueID {
+			return
+		}
 		if result.Success {
 			m.pendingCommitMsg = strings.TrimSpace(result.Result)
 			m.state = StateCommitConfirm
 			m.statusMsg = "Review commit message"
 		} else {
-			m.state = StateNormal
-			m.pendingCloseIssue = nil
-			m.statusMsg = fmt.Sprintf("Commit message generation failed: %s", result.IssueID)
+			*m = m.cancelCommit(fmt.Sprintf("Commit message generation failed: %s", result.IssueID))
 		}
 	case "update-changelog":
 		if result.Success {
@@ -894,6 +1707,15 @@ func (m *Model) handleResult(result claude.TaskResult) {
 
 // View implements tea.Model
 func (m Model) View() string {
+	view := m.render()
+	if m.ascii {
+		view = ui.ToASCII(view)
+	}
+	return view
+}
+
+// render draws the current state; View post-processes it for the terminal's charset
+func (m Model) render() string {
 	if m.width == 0 || m.height == 0 {
 		return "Loading..."
 	}
@@ -902,17 +1724,34 @@ func (m Model) View() string {
 	if m.state == StateOptionSelect {
 		return m.renderOptionSelectView()
 	}
+	if m.state == StateCompare {
+		return m.renderCompareView()
+	}
 
 	// Calculate layout - reserve 3 lines for header(1) + footer(1) + status(1)
-	listWidth := m.width / 2
+	listWidth := m.listPanelWidth()
 	previewWidth := m.width - listWidth
 	contentHeight := m.height - 3
 	if contentHeight < 1 {
 		contentHeight = 1
 	}
 
-	// Render header with scroll indicator
-	headerText := fmt.Sprintf("Issue Manager [%s]", m.filterMode)
+	// Render header with project name and scroll indicator
+	headerText := fmt.Sprintf("Issue Manager [%s, by %s]", m.filterMode, m.sortMode)
+	if m.projectName != "" {
+		// Keep the project name short enough to leave room for the rest of the header
+		name := runewidth.Truncate(m.projectName, max(m.width/3, 8), "…")
+		headerText = fmt.Sprintf("%s · %s", name, headerText)
+	}
+	if summary := m.statusSummary(); summary != "" {
+		headerText += " " + summary
+	}
+	if m.labelFilter != "" {
+		headerText += fmt.Sprintf(" #%s", m.labelFilter)
+	}
+	if m.search.active() {
+		headerText += fmt.Sprintf(" /%s", m.search.query)
+	}
 	// Add scroll indicator if there are more issues than visible
 	if len(m.issues) > contentHeight {
 		scrollInfo := fmt.Sprintf(" (%d-%d/%d)", m.listVOffset+1, min(m.listVOffset+contentHeight, len(m.issues)), len(m.issues))
@@ -924,24 +1763,23 @@ func (m Model) View() string {
 	}
 	header := m.styles.Header.Render(headerText)
 
-	// Render list panel
-	listContent := m.renderList(listWidth-2, contentHeight)
-	listPanel := m.styles.ListPanel.
-		Width(listWidth).
-		Render(listContent)
-
-	// Render preview panel
-	previewContent := m.renderPreview(previewWidth-4, contentHeight)
-	previewPanel := m.styles.PreviewPanel.
-		Width(previewWidth).
-		Render(previewContent)
-
-	// Combine panels horizontally
-	content := lipgloss.JoinHorizontal(lipgloss.Top, listPanel, previewPanel)
+	// Render visible panels and combine them horizontally
+	var panels []string
+	if m.layout != LayoutPreviewOnly {
+		listContent := m.renderList(listWidth-2, contentHeight)
+		panels = append(panels, m.styles.ListPanel.
+			Width(listWidth).
+			Render(listContent))
+	}
+	if m.layout != LayoutListOnly {
+		previewContent := m.renderPreview(previewWidth-4, contentHeight)
+		panels = append(panels, m.styles.PreviewPanel.
+			Width(previewWidth).
+			Render(previewContent))
+	}
+	content := lipgloss.JoinHorizontal(lipgloss.Top, panels...)
 
-	// Render footer
-	keys := "[n]ew [a]nalyze [R]eview [p]lan [P]lan-review [i]mplement [u]pdate-log [c]lose [d]iscard [e]dit [f]ilter [q]uit"
-	footer := m.styles.Footer.Render(keys)
+	footer := m.renderFooter()
 	status := m.styles.StatusBar.Render(m.statusMsg)
 
 	// Handle special states
@@ -961,6 +1799,20 @@ func (m Model) View() string {
 		overlay = m.renderCommitConfirmOverlay()
 	case StateCommitGenerating:
 		overlay = m.renderCommitGeneratingOverlay()
+	case StateDetail:
+		overlay = m.renderDetailOverlay()
+	case StateGitDiff:
+		overlay = m.renderGitDiffOverlay()
+	case StateVersions:
+		overlay = m.renderVersionsOverlay()
+	case StateVersionDiff:
+		overlay = m.renderVersionDiffOverlay()
+	case StateTemplateSelect:
+		overlay = m.renderTemplateSelectOverlay()
+	case StateChecklist:
+		overlay = m.renderChecklistOverlay()
+	case StateHelp:
+		overlay = m.renderHelpOverlay()
 	}
 
 	// Combine vertically
@@ -971,16 +1823,7 @@ func (m Model) View() string {
 		status,
 	)
 
-	// Force exact terminal height to prevent scrolling issues
-	lines := strings.Split(view, "\n")
-	if len(lines) > m.height {
-		lines = lines[:m.height]
-	}
-	for len(lines) < m.height {
-		lines = append(lines, "")
-	}
-
-	view = strings.Join(lines, "\n")
+	view = m.fitToHeight(view)
 
 	// Overlay popup on top of background
 	if overlay != "" {
@@ -990,6 +1833,18 @@ func (m Model) View() string {
 	return view
 }
 
+// fitToHeight forces the view to exactly m.height lines to prevent scrolling issues
+func (m Model) fitToHeight(view string) string {
+	lines := strings.Split(view, "\n")
+	if len(lines) > m.height {
+		lines = lines[:m.height]
+	}
+	for len(lines) < m.height {
+		lines = append(lines, "")
+	}
+	return strings.Join(lines, "\n")
+}
+
 // placeOverlay places the overlay centered on top of the background
 func placeOverlay(width, height int, overlay, background string) string {
 	overlayWidth := lipgloss.Width(overlay)
@@ -1120,6 +1975,14 @@ func (m Model) renderBaseOverlay(title, content, footer string, width int) strin
 	return OverlayStyles.Container.Width(width).Render(innerContent)
 }
 
+// listTitle prefixes the issue title with its priority marker, if any
+func listTitle(issue *model.Issue) string {
+	if marker := issue.Priority.Marker(); marker != "" {
+		return marker + " " + issue.Title
+	}
+	return issue.Title
+}
+
 func (m Model) renderList(width, height int) string {
 	var lines []string
 
@@ -1128,7 +1991,7 @@ func (m Model) renderList(width, height int) string {
 	} else {
 		// Calculate the visible range based on vertical scroll offset
 		startIdx := m.listVOffset
-		endIdx := m.listVOffset + height
+		endIdx := m.listVOffset + height/m.listRowHeight()
 		if endIdx > len(m.issues) {
 			endIdx = len(m.issues)
 		}
@@ -1141,20 +2004,29 @@ func (m Model) renderList(width, height int) string {
 			m.processingLock.Lock()
 			taskType, isProcessing := m.processing[issue.ID]
 			m.processingLock.Unlock()
+			isQueued := isProcessing && m.claude.Queued(issue.ID)
 
-			if isProcessing {
+			switch {
+			case isQueued:
+				icon = ui.IconQueued
+			case isProcessing:
 				icon = ui.SpinnerFrames[m.spinnerFrame]
-			} else {
+			case m.blocked[issue.ID]:
+				icon = ui.IconBlocked
+			default:
 				icon = issue.StatusIcon()
 			}
 
 			// Format line
 			typeIcon := issue.Type.Icon()
 			var suffix string
+			if progress, ok := m.taskProgress[issue.ID]; ok {
+				suffix = fmt.Sprintf(" (%s)", progress)
+			}
 			if isProcessing {
-				suffix = fmt.Sprintf(" [%s...]", taskType)
+				suffix += processingSuffix(taskType, isQueued)
 			}
-			line := fmt.Sprintf("%s %s [%s] %s%s", typeIcon, icon, issue.ID, issue.Title, suffix)
+			line := fmt.Sprintf("%s%s %s [%s] %s%s%s", m.markPrefix(issue), typeIcon, icon, issue.ID, listTitle(issue), labelBadges(issue.Labels), suffix)
 
 			// Apply horizontal scroll offset
 			if m.listHOffset > 0 {
@@ -1166,14 +2038,22 @@ func (m Model) renderList(width, height int) string {
 				line = runewidth.Truncate(line, width-3, "...")
 			}
 
-			// Style
-			if i == m.selected {
-				line = m.styles.SelectedItem.Render(line)
-			} else if isProcessing {
-				line = m.styles.ProcessingItem.Render(line)
+			// Style (selection and processing take precedence over status color)
+			style := m.styles.StatusStyle(issue.Status)
+			switch {
+			case i == m.selected:
+				style = m.styles.SelectedItem
+			case isQueued:
+				style = m.styles.QueuedItem
+			case isProcessing:
+				style = m.styles.ProcessingItem
 			}
+			line = highlightStyled(line, m.search.pattern, style)
 
 			lines = append(lines, line)
+			if m.listRowHeight() > 1 {
+				lines = append(lines, m.renderSummaryLine(issue, i == m.selected, width))
+			}
 		}
 	}
 
@@ -1185,6 +2065,22 @@ func (m Model) renderList(width, height int) string {
 	return strings.Join(lines, "\n")
 }
 
+// renderSummaryLine renders an issue's list subtitle, aligned under its title
+func (m Model) renderSummaryLine(issue *model.Issue, selected bool, width int) string {
+	prefix := fmt.Sprintf("%s%s %s [%s] ", m.markPrefix(issue), issue.Type.Icon(), issue.StatusIcon(), issue.ID)
+	line := strings.Repeat(" ", runewidth.StringWidth(prefix)) + m.summaries[issue.ID]
+	if m.listHOffset > 0 {
+		line = applyHorizontalOffsetToLine(line, m.listHOffset)
+	}
+	if runewidth.StringWidth(line) > width {
+		line = runewidth.Truncate(line, width-3, "...")
+	}
+	if selected {
+		return m.styles.SelectedItem.Render(line)
+	}
+	return m.styles.ListSummary.Render(line)
+}
+
 func (m Model) renderPreview(width, height int) string {
 	var lines []string
 
@@ -1194,28 +2090,62 @@ func (m Model) renderPreview(width, height int) string {
 		issue := m.issues[m.selected]
 
 		// Title
-		title := m.styles.PreviewTitle.Render(
-			fmt.Sprintf("Preview: %s", issue.ID),
-		)
+		progress, hasChecklist := m.taskProgress[issue.ID]
+		percent := issue.ProgressPercentWithChecklist(progress.done, progress.total)
+		heading := fmt.Sprintf("Preview: %s  %s %d%%", issue.ID, progressBar(percent, 10), percent)
+		if hasChecklist {
+			heading += fmt.Sprintf("  ☑ %s", progress)
+		}
+		if usage, err := m.storage.LoadUsage(issue.ID); err == nil && !usage.IsZero() {
+			heading += "  tokens " + usage.String()
+		}
+		if !issue.Updated.IsZero() {
+			heading += "  updated " + model.TimeAgo(issue.Updated, time.Now())
+		}
+		title := m.styles.PreviewTitle.Render(heading)
 		lines = append(lines, title)
 		lines = append(lines, strings.Repeat("─", min(width, 40)))
 
 		// Load content
 		brief, err := m.storage.LoadBrief(issue.ID)
 		var content string
+		rendered := false
 		if err != nil || brief == nil {
 			content = "brief.md not found"
 		} else {
 			content = brief.Content
 			if content == "" {
 				content = "(empty)"
+			} else if !m.rawMarkdown && !m.search.active() {
+				// Search highlights are matched against the raw text
+				content = m.markdown.render("brief/"+issue.ID, content, width)
+				rendered = true
 			}
 		}
 
 		// Wrap content to width and add lines
-		wrapped := wrapText(content, width)
-		contentLines := strings.Split(wrapped, "\n")
+		if !rendered {
+			content = wrapText(content, width)
+		}
+		contentLines := strings.Split(content, "\n")
+		if rendered {
+			// Code blocks aren't wrapped; the raw view [M] shows them in full
+			for i, line := range contentLines {
+				contentLines[i] = ansi.Truncate(line, width, "")
+			}
+		}
+		if m.search.active() {
+			for i, line := range contentLines {
+				contentLines[i] = highlightStyled(line, m.search.pattern, lipgloss.NewStyle())
+			}
+		}
 		lines = append(lines, contentLines...)
+
+		// Matches in the analysis and plan, which the preview doesn't show
+		if matchLines := m.searchMatchLines(issue.ID, width); len(matchLines) > 0 {
+			lines = append(lines, "", m.styles.PreviewTitle.Render("Matches"))
+			lines = append(lines, matchLines...)
+		}
 	}
 
 	// Pad to fill height to prevent layout shifts
@@ -1243,6 +2173,12 @@ func (m Model) renderInputOverlay() string {
 		title = "Plan Feedback"
 	case InputChangeReason:
 		title = "Update Change Log"
+	case InputMerge:
+		title = "Merge Issue"
+	case InputSearch:
+		title = "Search Issues"
+	case InputBulkConfirm:
+		title = fmt.Sprintf("%s Confirm Bulk Action", OverlayIcons.Confirm)
 	default:
 		title = "Input"
 	}
@@ -1267,9 +2203,14 @@ func (m Model) renderInputOverlay() string {
 		separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
 		inputSection := fmt.Sprintf("%s\n%s",
 			m.styles.InputPrompt.Render(m.inputPrompt),
-			m.textInput.View(),
+			m.feedbackInput.View(),
 		)
 
+		if len(m.feedbackHistory) > 0 {
+			history := OverlayStyles.Hint.Render("Previous feedback:\n" + strings.Join(m.feedbackHistory, "\n"))
+			inputSection = history + "\n" + inputSection
+		}
+
 		content := fmt.Sprintf("%s\n%s%s\n\n%s",
 			m.viewport.View(),
 			separator,
@@ -1277,7 +2218,7 @@ func (m Model) renderInputOverlay() string {
 			inputSection,
 		)
 
-		footer := "[Enter] Submit    [Esc] Back"
+		footer := "[Ctrl+D] Submit    [Enter] New line    [Esc] Back"
 
 		return m.renderBaseOverlay(title, content, footer, popupWidth)
 	}
@@ -1293,6 +2234,29 @@ func (m Model) renderInputOverlay() string {
 	return m.renderBaseOverlay(title, content, footer, 60)
 }
 
+// renderHelpOverlay lays out KeyMap.FullHelp as columns of key/description pairs
+func (m Model) renderHelpOverlay() string {
+	var columns []string
+	for _, group := range m.keys.FullHelp() {
+		keyWidth := 0
+		for _, b := range group {
+			keyWidth = max(keyWidth, runewidth.StringWidth(b.Help().Key))
+		}
+
+		var lines []string
+		for _, b := range group {
+			help := b.Help()
+			lines = append(lines, fmt.Sprintf("%s  %s",
+				OverlayStyles.Selected.Render(runewidth.FillRight(help.Key, keyWidth)),
+				help.Desc))
+		}
+		columns = append(columns, lipgloss.NewStyle().PaddingRight(4).Render(strings.Join(lines, "\n")))
+	}
+
+	content := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	return m.renderBaseOverlay("Keyboard Shortcuts", content, "Press any key to close", lipgloss.Width(content)+6)
+}
+
 func (m Model) renderConfirmOverlay() string {
 	// Build content with icon
 	content := fmt.Sprintf("%s %s", OverlayIcons.Confirm, m.confirmMsg)
@@ -1305,10 +2269,12 @@ func (m Model) renderConfirmOverlay() string {
 
 func (m Model) renderTypeSelectOverlay() string {
 	// Build options with icons on separate lines
-	options := fmt.Sprintf("  [f] Feature   %s\n  [b] Bug       %s\n  [r] Refactor  %s",
+	options := fmt.Sprintf("  [f] Feature   %s\n  [b] Bug       %s\n  [r] Refactor  %s\n\n  Priority: %s  %s",
 		model.TypeFeature.Icon(),
 		model.TypeBug.Icon(),
 		model.TypeRefactor.Icon(),
+		OverlayStyles.Selected.Render(string(m.pendingPriority)),
+		OverlayStyles.Hint.Render("[+/-]"),
 	)
 
 	// Footer with cancel hint
@@ -1339,15 +2305,23 @@ func (m Model) renderReviewPreviewOverlay() string {
 
 	// Build content with viewport and scroll info
 	separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
-	scrollHints := OverlayStyles.Hint.Render(scrollInfo + hScrollInfo)
+	scrollHints := OverlayStyles.Hint.Render(scrollInfo + hScrollInfo + m.findStatus())
 	content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, scrollHints)
 
 	// Footer with action hints
-	footer := "[e] Edit    [f] Feedback    [c] Close    ↑↓ Scroll    ←→ Pan"
+	footer := "[e] Edit    [f] Feedback    [p] Plan    [s] Side-by-side    [V] Versions    [d] Diff    [M] Raw    [b] Browser    [/] Find    [c] Close    ↑↓ Scroll    " + m.lineModeHint()
 
 	return m.renderBaseOverlay("Review Analysis", content, footer, popupWidth)
 }
 
+// lineModeHint describes the review overlays' long-line keys for the current mode
+func (m Model) lineModeHint() string {
+	if m.wrapPreview {
+		return "[w] Pan"
+	}
+	return "←→ Pan    [w] Wrap"
+}
+
 func (m Model) renderPlanPreviewOverlay() string {
 	// Calculate width based on terminal size
 	popupWidth := m.width - 10
@@ -1370,11 +2344,11 @@ func (m Model) renderPlanPreviewOverlay() string {
 
 	// Build content with viewport and scroll info
 	separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
-	scrollHints := OverlayStyles.Hint.Render(scrollInfo + hScrollInfo)
+	scrollHints := OverlayStyles.Hint.Render(scrollInfo + hScrollInfo + m.findStatus())
 	content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, scrollHints)
 
 	// Footer with action hints
-	footer := "[e] Edit    [f] Feedback    [c] Close    ↑↓ Scroll    ←→ Pan"
+	footer := "[e] Edit    [f] Feedback    [i] Implement    [s] Side-by-side    [V] Versions    [d] Diff    [M] Raw    [b] Browser    [/] Find    [c] Close    ↑↓ Scroll    " + m.lineModeHint()
 
 	return m.renderBaseOverlay("Review Plan", content, footer, popupWidth)
 }
@@ -1415,12 +2389,57 @@ func (m Model) renderCommitConfirmOverlay() string {
 	// Content with separator and message
 	separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
 	content := fmt.Sprintf("%s\n\n%s", separator, displayMsg)
+	if len(m.pendingCommitPaths) > 0 {
+		content += fmt.Sprintf("\n\n%s\n%s", separator, OverlayStyles.Hint.Render(
+			fmt.Sprintf("Commits only the %d plan and issue files", len(m.pendingCommitPaths))))
+	}
+	if m.pendingPlanReport != nil {
+		content += fmt.Sprintf("\n\n%s\n%s", separator, renderPlanReport(m.pendingPlanReport))
+	}
 
 	footer := "[y] Commit    [n] Cancel    ↑↓ Scroll"
 
 	return m.renderBaseOverlay(title, content, footer, popupWidth)
 }
 
+// renderPlanReport lists planned files that changed, planned files that didn't and
+// changes the plan didn't mention
+func renderPlanReport(report *storage.PlanDiffReport) string {
+	warn := lipgloss.NewStyle().Foreground(ui.ColorWarning)
+	ok := lipgloss.NewStyle().Foreground(ui.ColorSuccess)
+
+	title := "Plan vs diff: " + ok.Render("matches")
+	if !report.Matches() {
+		var parts []string
+		if n := len(report.Untouched); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d planned untouched", n))
+		}
+		if n := len(report.Unplanned); n > 0 {
+			parts = append(parts, fmt.Sprintf("%d unplanned", n))
+		}
+		title = "Plan vs diff: " + warn.Render(strings.Join(parts, ", "))
+	}
+	lines := []string{title}
+	for _, change := range report.Changed {
+		lines = append(lines, ok.Render(ui.IconSuccess)+" "+change.Path+" "+OverlayStyles.Hint.Render(changeStat(change)))
+	}
+	for _, path := range report.Untouched {
+		lines = append(lines, warn.Render(ui.IconStatusInvalid+" "+path+" (planned, unchanged)"))
+	}
+	for _, change := range report.Unplanned {
+		lines = append(lines, warn.Render("+ "+change.Path+" (not in plan)")+" "+OverlayStyles.Hint.Render(changeStat(change)))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// changeStat formats a file's diff line counts like git's --stat summary
+func changeStat(change storage.FileChange) string {
+	if change.Untracked {
+		return "new"
+	}
+	return fmt.Sprintf("+%d -%d", change.Added, change.Deleted)
+}
+
 func (m Model) renderCommitGeneratingOverlay() string {
 	spinner := ui.SpinnerFrames[m.spinnerFrame]
 
@@ -1433,10 +2452,33 @@ func (m Model) renderCommitGeneratingOverlay() string {
 	// Content with spinner animation
 	content := fmt.Sprintf("%s  Generating commit message...", spinner)
 
-	// No footer during processing (input is blocked)
-	footer := OverlayStyles.Hint.Render("Please wait...")
+	// Only Esc is accepted during processing
+	footer := OverlayStyles.Hint.Render("Please wait...    [Esc] Cancel")
+
+	return m.renderBaseOverlay(title, content, footer, 50)
+}
+
+func (m Model) renderDetailOverlay() string {
+	popupWidth := m.width - 10
+	if popupWidth < 60 {
+		popupWidth = 60
+	}
+	if popupWidth > 100 {
+		popupWidth = 100
+	}
+
+	title := "Issue Detail"
+	if issue := m.getSelectedIssue(); issue != nil {
+		title = fmt.Sprintf("%s %s [%s] %s", issue.Type.Icon(), issue.StatusIcon(), issue.ID, issue.Title)
+	}
+
+	scrollInfo := fmt.Sprintf(" %3.0f%% ", m.viewport.ScrollPercent()*100)
+	separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
+	content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, OverlayStyles.Hint.Render(scrollInfo))
 
-	return m.renderBaseOverlay(title, content, footer, 50)
+	footer := "[e] Edit    [Esc] Close    ↑↓ Scroll"
+
+	return m.renderBaseOverlay(title, content, footer, popupWidth)
 }
 
 // Actions
@@ -1450,8 +2492,25 @@ func (m Model) startNewIssue() (Model, tea.Cmd) {
 }
 
 func (m Model) createIssue(issueType model.IssueType) (Model, tea.Cmd) {
+	// Offer named templates when the project has any
+	if names, _ := m.storage.ListTemplates(); len(names) > 0 {
+		m.templates = templatePicker{names: names, issueType: issueType}
+		m.state = StateTemplateSelect
+		return m, nil
+	}
+	return m.createIssueFromTemplate(issueType, "")
+}
+
+// createIssueFromTemplate creates the pending issue seeded from the named template
+// (or the type default when name is empty) and opens it in the editor
+func (m Model) createIssueFromTemplate(issueType model.IssueType, templateName string) (Model, tea.Cmd) {
 	m.state = StateNormal
-	issue, err := m.storage.CreateIssue(m.pendingTitle, issueType, "")
+	body, err := m.storage.TemplateBody(templateName, issueType)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	issue, err := m.storage.CreateIssue(m.pendingTitle, issueType, m.pendingPriority, body)
 	if err != nil {
 		m.statusMsg = fmt.Sprintf("Error: %v", err)
 		return m, nil
@@ -1460,18 +2519,10 @@ func (m Model) createIssue(issueType model.IssueType) (Model, tea.Cmd) {
 
 	// Open editor for the new issue's brief.md
 	briefPath := m.storage.BriefPath(issue.ID)
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
-
-	cmd := exec.Command(editor, briefPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := m.editorCommand(briefPath)
 
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return syncAfterEditMsg{issueID: issue.ID}
+		return syncAfterEditMsg{issueID: issue.ID, created: true, err: err}
 	})
 }
 
@@ -1483,18 +2534,10 @@ func (m Model) editIssue() (Model, tea.Cmd) {
 	}
 
 	briefPath := m.storage.BriefPath(issue.ID)
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
-
-	cmd := exec.Command(editor, briefPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := m.editorCommand(briefPath)
 
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return syncAfterEditMsg{issueID: issue.ID}
+		return syncAfterEditMsg{issueID: issue.ID, err: err}
 	})
 }
 
@@ -1506,22 +2549,15 @@ func (m Model) editAnalysis() (Model, tea.Cmd) {
 	}
 
 	analysisPath := m.storage.AnalysisPath(issue.ID)
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
 
 	// Reset review state before opening editor
 	m.state = StateNormal
 	m.reviewAnalysis = ""
 
-	cmd := exec.Command(editor, analysisPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := m.editorCommand(analysisPath)
 
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return refreshRequestMsg{}
+		return refreshRequestMsg{editorErr: err}
 	})
 }
 
@@ -1533,22 +2569,15 @@ func (m Model) editPlan() (Model, tea.Cmd) {
 	}
 
 	planPath := m.storage.PlanPath(issue.ID)
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
 
 	// Reset plan review state before opening editor
 	m.state = StateNormal
 	m.reviewPlan = ""
 
-	cmd := exec.Command(editor, planPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := m.editorCommand(planPath)
 
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return refreshRequestMsg{}
+		return refreshRequestMsg{editorErr: err}
 	})
 }
 
@@ -1571,12 +2600,37 @@ func (m Model) confirmClose() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Check if there are staged changes
-	if !m.storage.HasStagedChanges() {
+	// Issue-only mode: commit just the issue's files, leave code changes alone
+	if m.config.Close.Mode == config.CloseModeIssueOnly {
+		m.state = StateConfirm
+		m.confirmMsg = fmt.Sprintf("Close %s and commit only its issue files?", issue.ID)
+		m.pendingIssueOnlyClose = issue
+		return m, nil
+	}
+
+	if m.config.Close.Mode == config.CloseModePlan {
+		// Plan mode: stage what the plan's Files Modified table lists
+		staging, err := m.storage.StagePlanFiles(issue.ID)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Close %s: %v", issue.ID, err)
+			return m, nil
+		}
+		if len(staging.Staged) == 0 {
+			m.statusMsg = fmt.Sprintf("None of the files in %s's plan have changes", issue.ID)
+			return m, nil
+		}
+		m.pendingCommitPaths = staging.Paths(m.storage, issue.ID)
+	} else if !m.storage.HasStagedChanges() {
+		// Check if there are staged changes
 		m.statusMsg = "No staged changes. Run 'git add' first"
 		return m, nil
 	}
 
+	// Read-only check of what the implementation changed against what was planned
+	if report, err := m.storage.ComparePlanToDiff(issue.ID); err == nil {
+		m.pendingPlanReport = report
+	}
+
 	// Git repo with staged changes: generate commit message with Haiku
 	m.pendingCloseIssue = issue
 	m.state = StateCommitGenerating
@@ -1585,13 +2639,79 @@ func (m Model) confirmClose() (Model, tea.Cmd) {
 	// Load plan.md for context
 	plan, _ := m.storage.LoadPlan(issue.ID)
 
-	prompt := claude.BuildCommitMessagePrompt(issue.ID, plan)
-	m.claude.RunAsync(issue.ID, "commit", prompt, "haiku", "", m.resultChan)
+	prompt := claude.BuildCommitMessagePrompt(issue.ID, plan, m.commitStyle(issue))
+	m.claude.RunAsync(m.ctx, issue.ID, "commit", prompt, m.config.Claude.ModelFor("commit"), "", m.resultChan)
 
 	return m, nil
 }
 
+// commitStyle maps the commit config onto the prompt's commit style for an issue
+func (m Model) commitStyle(issue *model.Issue) claude.CommitStyle {
+	style := claude.CommitStyle{Convention: string(m.config.Commit.Convention)}
+	switch m.config.Commit.Scope {
+	case config.CommitScopeType:
+		style.Scope = string(issue.Type)
+	case config.CommitScopeLabel:
+		style.Scope = string(issue.Type)
+		if len(issue.Labels) > 0 {
+			style.Scope = issue.Labels[0]
+		}
+	case config.CommitScopeNone:
+		style.NoScope = true
+	}
+	return style
+}
+
+// closeIssueFilesOnly closes the issue and commits only its own files as a chore commit
+func (m Model) closeIssueFilesOnly(issue *model.Issue) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.storage.UpdateIssueStatus(issue.ID, model.StatusClosed, ""); err != nil {
+			return issueOnlyClosedMsg{issueID: issue.ID, err: err}
+		}
+		message := fmt.Sprintf("chore: close #%s\n\n%s", issue.ID, issue.Title)
+		committed, _ := m.storage.CommitIssueFiles(issue.ID, message)
+		return issueOnlyClosedMsg{issueID: issue.ID, committed: committed}
+	}
+}
+
+// startMerge asks which issue the selected (duplicate) issue should be merged into
+func (m Model) startMerge() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	m.state = StateInput
+	m.inputMode = InputMerge
+	m.inputPrompt = fmt.Sprintf("Merge %s into issue ID: ", issue.ID)
+	m.textInput.Focus()
+	return m, textinput.Blink
+}
+
+// executeMerge folds the selected issue into keepID and closes it as a duplicate
+func (m Model) executeMerge(keepID string) (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil || keepID == "" {
+		m.statusMsg = "Cancelled"
+		return m, nil
+	}
+
+	if err := m.storage.MergeIssues(keepID, issue.ID); err != nil {
+		m.statusMsg = fmt.Sprintf("Merge failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Merged %s into %s", issue.ID, keepID)
+	return m, m.refreshIssues()
+}
+
 func (m Model) confirmDiscard() (Model, tea.Cmd) {
+	if len(m.marked) > 0 {
+		return m.confirmBulk(bulkAction{verb: "discard", done: "Discarded", run: func(id string) error {
+			return m.storage.UpdateIssueStatus(id, model.StatusInvalid, "Discarded by user")
+		}})
+	}
+
 	issue := m.getSelectedIssue()
 	if issue == nil {
 		m.statusMsg = "No issue selected"
@@ -1600,13 +2720,60 @@ func (m Model) confirmDiscard() (Model, tea.Cmd) {
 
 	m.state = StateConfirm
 	m.confirmMsg = fmt.Sprintf("Discard issue %s?", issue.ID)
-	m.confirmAction = func() {
-		_ = m.storage.UpdateIssueStatus(issue.ID, model.StatusInvalid, "Discarded by user")
-		m.statusMsg = fmt.Sprintf("Discarded %s", issue.ID)
+	m.confirmCmd = func() tea.Msg {
+		if err := m.storage.UpdateIssueStatus(issue.ID, model.StatusInvalid, "Discarded by user"); err != nil {
+			return actionDoneMsg{status: fmt.Sprintf("Discard %s failed: %v", issue.ID, err)}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("Discarded %s", issue.ID)}
+	}
+	return m, nil
+}
+
+func (m Model) confirmDelete() (Model, tea.Cmd) {
+	if len(m.marked) > 0 {
+		return m.confirmBulk(bulkAction{verb: "permanently delete", done: "Deleted", run: m.storage.DeleteIssue})
+	}
+
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
 	}
+
+	m.state = StateConfirm
+	m.confirmMsg = fmt.Sprintf("Permanently delete issue %s and all its files?", issue.ID)
+	m.confirmCmd = m.deleteIssueCmd(issue.ID)
 	return m, nil
 }
 
+// deleteIssueCmd deletes an issue and all its files
+func (m Model) deleteIssueCmd(issueID string) tea.Cmd {
+	return func() tea.Msg {
+		if err := m.storage.DeleteIssue(issueID); err != nil {
+			return actionDoneMsg{status: fmt.Sprintf("Delete %s failed: %v", issueID, err)}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("Deleted %s", issueID)}
+	}
+}
+
+// allowOnClosed rejects AI actions on closed/invalid issues. Pressing the same key
+// again right away overrides the guard for that one action.
+func (m *Model) allowOnClosed(action, keyHint, armed string) bool {
+	issue := m.getSelectedIssue()
+	if issue == nil || !issue.Status.IsClosed() {
+		return true
+	}
+
+	token := action + ":" + issue.ID
+	if armed == token {
+		return true
+	}
+
+	m.closedOverride = token
+	m.statusMsg = fmt.Sprintf("%s is %s - reopen it first, or press %s again to %s anyway", issue.ID, issue.Status, keyHint, action)
+	return false
+}
+
 func (m Model) analyzeIssue() (Model, tea.Cmd) {
 	issue := m.getSelectedIssue()
 	if issue == nil {
@@ -1654,10 +2821,10 @@ func (m *Model) executeAnalyze() {
 
 	briefPath := m.storage.BriefPath(issue.ID)
 	// Use JSON prompt for structured output
-	prompt := claude.BuildAnalysisPromptJSON(brief.Content, briefPath)
+	prompt := m.withLabelGuidance(claude.BuildAnalysisPromptJSON(brief.Content, briefPath, brief.Type), brief)
 
 	m.statusMsg = fmt.Sprintf("Analyzing %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "analyze", prompt, "", "", m.resultChan)
+	m.runStreaming(issue.ID, "analyze", prompt, "")
 }
 
 func (m Model) executeAnalyzeFor(issue *model.Issue) (Model, tea.Cmd) {
@@ -1673,10 +2840,10 @@ func (m Model) executeAnalyzeFor(issue *model.Issue) (Model, tea.Cmd) {
 
 	briefPath := m.storage.BriefPath(issue.ID)
 	// Use JSON prompt for structured output
-	prompt := claude.BuildAnalysisPromptJSON(brief.Content, briefPath)
+	prompt := m.withLabelGuidance(claude.BuildAnalysisPromptJSON(brief.Content, briefPath, brief.Type), brief)
 
 	m.statusMsg = fmt.Sprintf("Analyzing %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "analyze", prompt, "", "", m.resultChan)
+	m.runStreaming(issue.ID, "analyze", prompt, "")
 
 	return m, nil
 }
@@ -1749,19 +2916,19 @@ func (m *Model) executePlan() {
 	if m.storage.AnalysisJSONExists(issue.ID) {
 		analysis, err := m.storage.LoadAnalysisJSON(issue.ID)
 		if err == nil && analysis != nil {
-			prompt := claude.BuildPlanPromptWithOption(brief.Content, analysis)
+			prompt := m.withLabelGuidance(claude.BuildPlanPromptWithOption(brief.Content, analysis), brief)
 			m.statusMsg = fmt.Sprintf("Planning %s with selected option...", issue.ID)
-			m.claude.RunAsync(issue.ID, "plan", prompt, "", sessionID, m.resultChan)
+			m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "plan", prompt, m.config.Claude.ModelFor("plan"), sessionID, m.resultChan)
 			return
 		}
 	}
 
 	// Fall back to markdown analysis
 	analysisContent, _ := m.storage.LoadAnalysis(issue.ID)
-	prompt := claude.BuildPlanPrompt(brief.Content, analysisContent)
+	prompt := m.withLabelGuidance(claude.BuildPlanPrompt(brief.Content, analysisContent), brief)
 
 	m.statusMsg = fmt.Sprintf("Planning %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "plan", prompt, "", sessionID, m.resultChan)
+	m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "plan", prompt, m.config.Claude.ModelFor("plan"), sessionID, m.resultChan)
 }
 
 func (m Model) executePlanFor(issue *model.Issue) (Model, tea.Cmd) {
@@ -1776,19 +2943,19 @@ func (m Model) executePlanFor(issue *model.Issue) (Model, tea.Cmd) {
 	if m.storage.AnalysisJSONExists(issue.ID) {
 		analysis, err := m.storage.LoadAnalysisJSON(issue.ID)
 		if err == nil && analysis != nil {
-			prompt := claude.BuildPlanPromptWithOption(brief.Content, analysis)
+			prompt := m.withLabelGuidance(claude.BuildPlanPromptWithOption(brief.Content, analysis), brief)
 			m.statusMsg = fmt.Sprintf("Planning %s with selected option...", issue.ID)
-			m.claude.RunAsync(issue.ID, "plan", prompt, "", sessionID, m.resultChan)
+			m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "plan", prompt, m.config.Claude.ModelFor("plan"), sessionID, m.resultChan)
 			return m, nil
 		}
 	}
 
 	// Fall back to markdown analysis
 	analysisContent, _ := m.storage.LoadAnalysis(issue.ID)
-	prompt := claude.BuildPlanPrompt(brief.Content, analysisContent)
+	prompt := m.withLabelGuidance(claude.BuildPlanPrompt(brief.Content, analysisContent), brief)
 
 	m.statusMsg = fmt.Sprintf("Planning %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "plan", prompt, "", sessionID, m.resultChan)
+	m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "plan", prompt, m.config.Claude.ModelFor("plan"), sessionID, m.resultChan)
 
 	return m, nil
 }
@@ -1846,14 +3013,15 @@ func (m Model) reviewIssue() (Model, tea.Cmd) {
 	m.viewport.Width = viewportWidth
 	m.viewport.Height = viewportHeight
 
-	// Initialize horizontal scroll state
-	m.hOffset = 0
-	m.maxLineWidth = calculateMaxLineWidth(analysis)
-	m.viewport.SetContent(analysis)
+	// Enter review preview mode first: previewText depends on the state
+	m.state = StateReviewPreview
+
+	// Show the document from the top, without a find query
+	m.find = findState{}
+	m.reloadPreviewText()
 	m.viewport.GotoTop()
+	m.restoreReadingPosition("analysis", issue.ID, analysis)
 
-	// Enter review preview mode
-	m.state = StateReviewPreview
 	return m, nil
 }
 
@@ -1900,17 +3068,63 @@ func (m Model) planReviewIssue() (Model, tea.Cmd) {
 	m.viewport.Width = viewportWidth
 	m.viewport.Height = viewportHeight
 
-	// Initialize horizontal scroll state
-	m.hOffset = 0
-	m.maxLineWidth = calculateMaxLineWidth(plan)
-	m.viewport.SetContent(plan)
+	// Enter plan preview mode first: previewText depends on the state
+	m.state = StatePlanPreview
+
+	// Show the document from the top, without a find query
+	m.find = findState{}
+	m.reloadPreviewText()
 	m.viewport.GotoTop()
+	m.restoreReadingPosition("plan", issue.ID, plan)
 
-	// Enter plan preview mode
-	m.state = StatePlanPreview
 	return m, nil
 }
 
+// openDetail shows the selected issue's brief in a full-screen overlay,
+// keeping it readable when the preview pane is hidden
+func (m Model) openDetail() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	brief, err := m.storage.LoadBrief(issue.ID)
+	if err != nil || brief == nil {
+		m.statusMsg = "brief.md not found"
+		return m, nil
+	}
+	content := brief.Content
+	if content == "" {
+		content = "(empty)"
+	}
+
+	m.sizeOverlayViewport()
+	m.detailContent = wrapText(content, m.viewport.Width)
+	m.viewport.SetContent(m.detailContent)
+	m.viewport.GotoTop()
+
+	m.state = StateDetail
+	return m, nil
+}
+
+// sizeOverlayViewport sizes the shared viewport to fit inside a popup overlay
+func (m *Model) sizeOverlayViewport() {
+	viewportHeight := m.height - 10
+	if viewportHeight < 10 {
+		viewportHeight = 10
+	}
+	viewportWidth := m.width - 14
+	if viewportWidth < 50 {
+		viewportWidth = 50
+	}
+	if viewportWidth > 96 {
+		viewportWidth = 96
+	}
+	m.viewport.Width = viewportWidth
+	m.viewport.Height = viewportHeight
+}
+
 func (m Model) executeReview(feedback string) (Model, tea.Cmd) {
 	issue := m.getSelectedIssue()
 	if issue == nil {
@@ -1927,13 +3141,15 @@ func (m Model) executeReview(feedback string) (Model, tea.Cmd) {
 	m.processing[issue.ID] = "review"
 	m.processingLock.Unlock()
 
+	_ = m.storage.AppendFeedback(issue.ID, "**Analysis:** "+feedback)
+
 	analysisPath := m.storage.AnalysisPath(issue.ID)
 	sessionID, _ := m.storage.LoadSessionID(issue.ID)
 
-	prompt := claude.BuildReviewPrompt(analysisPath, feedback)
+	prompt := claude.BuildReviewPrompt(analysisPath, feedback, m.config.Claude.ReadOnly)
 
 	m.statusMsg = fmt.Sprintf("Reviewing %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "review", prompt, "", sessionID, m.resultChan)
+	m.runStreaming(issue.ID, "review", prompt, sessionID)
 
 	return m, nil
 }
@@ -1954,13 +3170,15 @@ func (m Model) executePlanReview(feedback string) (Model, tea.Cmd) {
 	m.processing[issue.ID] = "plan-review"
 	m.processingLock.Unlock()
 
+	_ = m.storage.AppendFeedback(issue.ID, "**Plan:** "+feedback)
+
 	planPath := m.storage.PlanPath(issue.ID)
 	sessionID, _ := m.storage.LoadSessionID(issue.ID)
 
-	prompt := claude.BuildPlanReviewPrompt(planPath, feedback)
+	prompt := claude.BuildPlanReviewPrompt(planPath, feedback, m.config.Claude.ReadOnly)
 
 	m.statusMsg = fmt.Sprintf("Reviewing plan %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "plan-review", prompt, "", sessionID, m.resultChan)
+	m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "plan-review", prompt, m.config.Claude.ModelFor("plan-review"), sessionID, m.resultChan)
 
 	return m, nil
 }
@@ -1984,37 +3202,84 @@ func (m Model) implementIssue() (Model, tea.Cmd) {
 		return m, nil
 	}
 
-	// Check if session exists for --resume
-	sessionID, _ := m.storage.LoadSessionID(issue.ID)
-	if sessionID == "" {
-		m.statusMsg = "No session found. Re-analyze the issue first"
-		return m, nil
+	if idx, err := m.storage.LoadIndex(); err == nil {
+		if blockers := idx.Blockers(issue.ID); len(blockers) > 0 {
+			m.statusMsg = fmt.Sprintf("%s is blocked by %s - close that first", issue.ID, strings.Join(blockers, ", "))
+			return m, nil
+		}
 	}
 
 	// Implement always requires confirmation as it may modify code
 	m.state = StateConfirm
 	m.confirmMsg = fmt.Sprintf("Implement %s? This may modify code.", issue.ID)
+	if sessionID, _ := m.storage.LoadSessionID(issue.ID); sessionID == "" {
+		m.confirmMsg = fmt.Sprintf("Implement %s? This may modify code. No Claude session to resume: a fresh session starts with the brief and plan as context.", issue.ID)
+	}
 	m.pendingRetryIssue = issue
 	m.pendingImplement = true
 	m.confirmAction = nil // Will be handled specially in handleConfirmKey
 	return m, nil
 }
 
+// checkoutIssueBranch switches to the selected issue's git branch, creating it if needed
+func (m Model) checkoutIssueBranch() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+	branch, err := m.storage.CreateIssueBranch(issue.ID, issue.Title)
+	switch {
+	case err != nil:
+		m.statusMsg = fmt.Sprintf("Branch failed: %v", err)
+	case branch == "":
+		m.statusMsg = "Not a git repository: no branch created"
+	default:
+		m.statusMsg = fmt.Sprintf("Switched to %s", branch)
+	}
+	return m, nil
+}
+
 func (m Model) executeImplementFor(issue *model.Issue) (Model, tea.Cmd) {
-	// Stage issue files before implementation
+	var onBranch string
+	if m.config.Implement.CreateBranch {
+		branch, err := m.storage.CreateIssueBranch(issue.ID, issue.Title)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Implement %s: %v", issue.ID, err)
+			return m, nil
+		}
+		if branch != "" {
+			onBranch = " on " + branch
+		}
+	}
+
+	// Stage confirmed brief/analysis/plan as the baseline so the later diff is code-only
 	m.storage.StageIssueFiles(issue.ID)
 
 	sessionID, _ := m.storage.LoadSessionID(issue.ID)
 	planPath := m.storage.PlanPath(issue.ID)
-	prompt := claude.BuildImplementPrompt(planPath)
+	args := []string{"--resume", sessionID, claude.BuildImplementPrompt(planPath)}
+	if sessionID == "" {
+		// No session to resume (e.g. cleared): start fresh with the documents as context
+		brief, err := m.storage.LoadBrief(issue.ID)
+		if err != nil || brief == nil {
+			m.statusMsg = fmt.Sprintf("Failed to load %s's brief: %v", issue.ID, err)
+			return m, nil
+		}
+		plan, err := m.storage.LoadPlan(issue.ID)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Failed to load %s's plan: %v", issue.ID, err)
+			return m, nil
+		}
+		args = []string{claude.BuildFreshImplementPrompt(brief.Content, plan, planPath)}
+	}
 
-	cmd := exec.Command("claude", "--resume", sessionID, prompt)
-	cmd.Dir = m.claude.WorkingDir
+	cmd := m.claude.Command(args...)
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
-	m.statusMsg = fmt.Sprintf("Implementing %s...", issue.ID)
+	m.statusMsg = fmt.Sprintf("Implementing %s%s...", issue.ID, onBranch)
 
 	issueID := issue.ID
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
@@ -2085,11 +3350,24 @@ func (m Model) executeUpdateChangeLog(changeReason string) (Model, tea.Cmd) {
 	// Build prompt and run Claude
 	prompt := claude.BuildChangeLogPrompt(planContent, gitDiff, changeReason)
 	m.statusMsg = fmt.Sprintf("Generating change log for %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "update-changelog", prompt, "haiku", "", m.resultChan)
+	m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "update-changelog", prompt, m.config.Claude.ModelFor("update-changelog"), "", m.resultChan)
 
 	return m, nil
 }
 
+// Cleanup kills running Claude calls and removes temporary files created during the session
+func (m Model) Cleanup() {
+	m.saveRecent()
+	m.cancel()
+	m.storage.CleanupTempFiles()
+	m.notifier.Wait()
+}
+
+// Timings returns a summary of Claude call timings recorded during the session
+func (m Model) Timings() string {
+	return m.claude.Metrics.Summary()
+}
+
 func (m Model) getSelectedIssue() *model.Issue {
 	if m.selected >= 0 && m.selected < len(m.issues) {
 		return m.issues[m.selected]
@@ -2126,6 +3404,35 @@ func wrapText(text string, width int) string {
 	return result.String()
 }
 
+// recentFeedbackLines returns up to n of the most recent non-heading lines from feedback.md
+func recentFeedbackLines(history string, n int) []string {
+	var lines []string
+	for _, line := range strings.Split(history, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, "  "+line)
+	}
+	if len(lines) > n {
+		lines = lines[len(lines)-n:]
+	}
+	return lines
+}
+
+// progressBar renders percent as a bar of the given number of cells
+func progressBar(percent, cells int) string {
+	filled := min(cells, max(0, percent*cells/100))
+	return strings.Repeat("▰", filled) + strings.Repeat("▱", cells-filled)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "yes"
+	}
+	return "no"
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -2153,6 +3460,15 @@ func calculateMaxLineWidth(content string) int {
 	return maxWidth
 }
 
+// panPreview scrolls the review preview sideways by delta and re-renders it
+func (m *Model) panPreview(delta int) {
+	offset := panOffset(m.hOffset, delta, m.maxLineWidth, m.viewport.Width)
+	if offset != m.hOffset {
+		m.hOffset = offset
+		m.setPreviewContent(m.previewText())
+	}
+}
+
 // applyHorizontalOffset applies horizontal scrolling to content
 // It returns content where each line is shifted by offset and truncated to width
 func applyHorizontalOffset(content string, offset int, width int) string {
@@ -2235,6 +3551,85 @@ func applyHorizontalOffsetToLine(line string, offset int) string {
 	return remaining
 }
 
+// statusSummary renders the non-zero active status counts, e.g. "3 open · 2 planned"
+func (m Model) statusSummary() string {
+	var parts []string
+	for _, status := range []model.IssueStatus{
+		model.StatusOpen,
+		model.StatusAnalyzed,
+		model.StatusPlanned,
+		model.StatusImplemented,
+	} {
+		if n := m.statusCounts[status]; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", n, status))
+		}
+	}
+	return strings.Join(parts, " · ")
+}
+
+// listPanelWidth returns the width of the issue list panel for the current layout
+func (m Model) listPanelWidth() int {
+	switch m.layout {
+	case LayoutListOnly:
+		return m.width
+	case LayoutPreviewOnly:
+		return 0
+	}
+	return int(float64(m.width) * m.splitRatio)
+}
+
+// adjustSplitRatio changes the list/preview split by delta, clamped to sane bounds,
+// and persists the new ratio for future sessions
+func (m Model) adjustSplitRatio(delta float64) Model {
+	ratio := m.splitRatio + delta
+	if ratio < minSplitRatio {
+		ratio = minSplitRatio
+	}
+	if ratio > maxSplitRatio {
+		ratio = maxSplitRatio
+	}
+	m.splitRatio = ratio
+	m.clampListHOffset()
+
+	m.prefs.SplitRatio = ratio
+	_ = m.prefs.Save()
+
+	m.statusMsg = fmt.Sprintf("List width: %.0f%%", ratio*100)
+	return m
+}
+
+// clampListHOffset keeps the list's horizontal scroll offset within the content width
+func (m *Model) clampListHOffset() {
+	m.listHOffset = panOffset(m.listHOffset, 0, m.listMaxLineWidth, m.listPanelWidth()-2)
+}
+
+// panOffset moves a horizontal scroll offset by delta, keeping it between 0 and
+// the point where the widest line's end reaches the right edge of the view
+func panOffset(offset, delta, contentWidth, viewWidth int) int {
+	maxOffset := max(0, contentWidth-viewWidth)
+	return min(max(offset+delta, 0), maxOffset)
+}
+
+// selectedID returns the selected issue's ID, or "" if the list is empty
+func (m Model) selectedID() string {
+	if issue := m.getSelectedIssue(); issue != nil {
+		return issue.ID
+	}
+	return ""
+}
+
+// reselect moves the selection to issue id after the list was reloaded, so a
+// refresh, re-sort or filter change doesn't move the cursor to another issue. If
+// the issue is no longer listed, the selection stays at the same position, clamped
+// to the new list.
+func (m *Model) reselect(id string) {
+	if i := slices.IndexFunc(m.issues, func(issue *model.Issue) bool { return issue.ID == id }); i >= 0 {
+		m.selected = i
+		return
+	}
+	m.selected = max(0, min(m.selected, len(m.issues)-1))
+}
+
 // ensureSelectedVisible adjusts listVOffset so that the selected item is visible
 func (m *Model) ensureSelectedVisible(visibleHeight int) {
 	if len(m.issues) == 0 || visibleHeight <= 0 {
@@ -2272,6 +3667,15 @@ func (m *Model) ensureSelectedVisible(visibleHeight int) {
 	}
 }
 
+// processingSuffix labels a list row whose Claude task is running or, when queued,
+// waiting for a free slot
+func processingSuffix(taskType string, queued bool) string {
+	if queued {
+		return fmt.Sprintf(" [%s queued]", taskType)
+	}
+	return fmt.Sprintf(" [%s...]", taskType)
+}
+
 // calculateListMaxLineWidth calculates the maximum line width for issue list items
 func (m *Model) calculateListMaxLineWidth() {
 	m.listMaxLineWidth = 0
@@ -2282,9 +3686,9 @@ func (m *Model) calculateListMaxLineWidth() {
 
 		var suffix string
 		if isProcessing {
-			suffix = fmt.Sprintf(" [%s...]", taskType)
+			suffix = processingSuffix(taskType, m.claude.Queued(issue.ID))
 		}
-		line := fmt.Sprintf("%s %s [%s] %s%s", issue.Type.Icon(), issue.StatusIcon(), issue.ID, issue.Title, suffix)
+		line := fmt.Sprintf("%s%s %s [%s] %s%s%s", m.markPrefix(issue), issue.Type.Icon(), issue.StatusIcon(), issue.ID, listTitle(issue), labelBadges(issue.Labels), suffix)
 		w := runewidth.StringWidth(line)
 		if w > m.listMaxLineWidth {
 			m.listMaxLineWidth = w
@@ -2304,6 +3708,10 @@ func (m Model) handleOptionSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Horizontal scroll step size for detail panel
 	const detailHScrollStep = 5
 
+	if key.Matches(msg, m.keys.Escape) {
+		return m.closeOptionSelect(), nil
+	}
+
 	switch msg.String() {
 	// Navigation (option selection)
 	case "up", "k":
@@ -2337,24 +3745,26 @@ func (m Model) handleOptionSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 
 	// Scroll detail viewport - horizontal (Ctrl + left/right/h/l)
 	case "ctrl+left", "ctrl+h":
-		if m.detailHOffset > 0 {
-			m.detailHOffset -= detailHScrollStep
-			if m.detailHOffset < 0 {
-				m.detailHOffset = 0
-			}
-		}
+		m.detailHOffset = panOffset(m.detailHOffset, -detailHScrollStep, m.detailMaxLineWidth, m.detailViewport.Width)
 		return m, nil
 	case "ctrl+right", "ctrl+l":
-		maxOffset := m.detailMaxLineWidth - m.detailViewport.Width
-		if maxOffset < 0 {
-			maxOffset = 0
-		}
-		if m.detailHOffset < maxOffset {
-			m.detailHOffset += detailHScrollStep
-			if m.detailHOffset > maxOffset {
-				m.detailHOffset = maxOffset
-			}
+		m.detailHOffset = panOffset(m.detailHOffset, detailHScrollStep, m.detailMaxLineWidth, m.detailViewport.Width)
+		return m, nil
+
+	// Select without planning yet; a later [p] plans with it
+	case " ":
+		selectedOption := m.analysis.Options[m.optionCursor]
+		issue := m.getSelectedIssue()
+		if issue == nil {
+			m.statusMsg = "No issue selected"
+			return m, nil
 		}
+		if err := m.storage.UpdateSelectedOption(issue.ID, selectedOption.ID); err != nil {
+			m.statusMsg = fmt.Sprintf("Failed to save selection: %v", err)
+			return m, nil
+		}
+		_ = m.analysis.SetSelectedOption(selectedOption.ID)
+		m.statusMsg = fmt.Sprintf("Selected: %s", selectedOption.Title)
 		return m, nil
 
 	// Select and proceed to plan
@@ -2391,16 +3801,21 @@ func (m Model) handleOptionSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.editAnalysisJSON()
 
 	// Cancel
-	case "esc", "q":
-		m.state = StateNormal
-		m.analysis = nil
-		m.statusMsg = "Cancelled option selection"
-		return m, nil
+	case "q":
+		return m.closeOptionSelect(), nil
 	}
 
 	return m, nil
 }
 
+// closeOptionSelect leaves the option selection screen without selecting
+func (m Model) closeOptionSelect() Model {
+	m.state = StateNormal
+	m.analysis = nil
+	m.statusMsg = "Cancelled option selection"
+	return m
+}
+
 func (m *Model) updateDetailViewport() {
 	if m.analysis == nil || m.optionCursor >= len(m.analysis.Options) {
 		return
@@ -2464,23 +3879,14 @@ func (m Model) renderOptionSelectView() string {
 	content := lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel)
 
 	// Footer
-	keys := "[↑/↓] Navigate  [Ctrl+↑↓←→/hjkl] Scroll Detail  [Enter] Select & Plan  [n] Add Option  [e] Edit  [Esc] Cancel"
+	keys := "[↑/↓] Navigate  [Ctrl+↑↓←→/hjkl] Scroll Detail  [Space] Select  [Enter] Select & Plan  [n] Add Option  [e] Edit  [Esc] Cancel"
 	footer := m.styles.Footer.Render(keys)
 	status := m.styles.StatusBar.Render(m.statusMsg)
 
 	// Combine vertically
 	view := lipgloss.JoinVertical(lipgloss.Left, header, content, footer, status)
 
-	// Force exact terminal height
-	lines := strings.Split(view, "\n")
-	if len(lines) > m.height {
-		lines = lines[:m.height]
-	}
-	for len(lines) < m.height {
-		lines = append(lines, "")
-	}
-
-	return strings.Join(lines, "\n")
+	return m.fitToHeight(view)
 }
 
 func (m Model) renderLeftPanel(width, summaryHeight, optionsHeight int) string {
@@ -2675,7 +4081,7 @@ func (m Model) renderOptionDetail(option *model.AnalysisOption) string {
 func (m Model) enterOptionSelectState(analysis *model.Analysis) Model {
 	m.state = StateOptionSelect
 	m.analysis = analysis
-	m.optionCursor = analysis.GetRecommendedIndex()
+	m.optionCursor = analysis.GetSelectedIndex()
 
 	// Setup viewports
 	contentHeight := m.height - 3
@@ -2709,22 +4115,15 @@ func (m Model) editAnalysisJSON() (Model, tea.Cmd) {
 	}
 
 	analysisPath := m.storage.AnalysisJSONPath(issue.ID)
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vim"
-	}
 
 	// Reset state before opening editor
 	m.state = StateNormal
 	m.analysis = nil
 
-	cmd := exec.Command(editor, analysisPath)
-	cmd.Stdin = os.Stdin
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := m.editorCommand(analysisPath)
 
 	return m, tea.ExecProcess(cmd, func(err error) tea.Msg {
-		return refreshRequestMsg{}
+		return refreshRequestMsg{editorErr: err}
 	})
 }
 
@@ -2753,7 +4152,7 @@ func (m Model) executeAddOption(description string) (Model, tea.Cmd) {
 	prompt := claude.BuildAddOptionPrompt(m.analysis, description)
 
 	m.statusMsg = fmt.Sprintf("Adding option to %s...", issue.ID)
-	m.claude.RunAsync(issue.ID, "add-option", prompt, "", sessionID, m.resultChan)
+	m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "add-option", prompt, m.config.Claude.ModelFor("add-option"), sessionID, m.resultChan)
 
 	return m, nil
 }
@@ -2779,10 +4178,10 @@ func (m Model) executePlanWithOption(issue *model.Issue) (Model, tea.Cmd) {
 	}
 
 	sessionID, _ := m.storage.LoadSessionID(issue.ID)
-	prompt := claude.BuildPlanPromptWithOption(brief.Content, analysis)
+	prompt := m.withLabelGuidance(claude.BuildPlanPromptWithOption(brief.Content, analysis), brief)
 
 	m.statusMsg = fmt.Sprintf("Planning %s with selected option...", issue.ID)
-	m.claude.RunAsync(issue.ID, "plan", prompt, "", sessionID, m.resultChan)
+	m.claude.RunAsync(m.taskContext(issue.ID), issue.ID, "plan", prompt, m.config.Claude.ModelFor("plan"), sessionID, m.resultChan)
 
 	return m, nil
 }
@@ -0,0 +1,193 @@
+package tui
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/config"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+func TestPanOffset(t *testing.T) {
+	tests := []struct {
+		name                                   string
+		offset, delta, contentWidth, viewWidth int
+		want                                   int
+	}{
+		{"pan right", 0, 10, 100, 40, 10},
+		{"pan left", 20, -10, 100, 40, 10},
+		{"stops at zero", 5, -10, 100, 40, 0},
+		{"stops where the widest line ends", 55, 10, 100, 40, 60},
+		{"already at the end", 60, 10, 100, 40, 60},
+		{"content fits", 0, 10, 30, 40, 0},
+		{"content exactly fits", 0, 10, 40, 40, 0},
+		{"reclamped after the content shrank", 60, 0, 50, 40, 10},
+		{"reclamped after the view widened", 60, 0, 100, 120, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := panOffset(tt.offset, tt.delta, tt.contentWidth, tt.viewWidth); got != tt.want {
+				t.Errorf("panOffset(%d, %d, %d, %d) = %d, want %d", tt.offset, tt.delta, tt.contentWidth, tt.viewWidth, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewPanStopsAtWidestLine(t *testing.T) {
+	m := testModel(t)
+	must(t, m.storage.SaveAnalysis(m.selectedID(), "## Summary\n```\n"+strings.Repeat("x", 300)+"\n```\n## Options\nfoo"))
+	m = press(t, m, "R")
+	if m.state != StateReviewPreview {
+		t.Fatalf("state %s, want ReviewPreview", m.state)
+	}
+	maxOffset := m.maxLineWidth - m.viewport.Width
+	if maxOffset <= 0 {
+		t.Fatalf("widest line %d fits the %d-column view", m.maxLineWidth, m.viewport.Width)
+	}
+
+	for range 100 {
+		m = press(t, m, "l")
+	}
+	if m.hOffset != maxOffset {
+		t.Errorf("offset after panning right = %d, want %d", m.hOffset, maxOffset)
+	}
+	for range 100 {
+		m = press(t, m, "h")
+	}
+	if m.hOffset != 0 {
+		t.Errorf("offset after panning left = %d, want 0", m.hOffset)
+	}
+}
+
+// listedIDs returns the IDs of the issue list in display order
+func listedIDs(m Model) []string {
+	var ids []string
+	for _, issue := range m.issues {
+		ids = append(ids, issue.ID)
+	}
+	return ids
+}
+
+func TestSortKeepsSelection(t *testing.T) {
+	m := testModel(t)
+	m = press(t, m, "j")
+	if m.selectedID() != "0002" {
+		t.Fatalf("selected %s, want 0002", m.selectedID())
+	}
+
+	orders := map[string]bool{}
+	for range sortModeCount {
+		m = press(t, m, "s")
+		orders[strings.Join(listedIDs(m), ",")] = true
+		if got := m.selectedID(); got != "0002" {
+			t.Errorf("sorted by %s (%v): selected %s, want 0002", m.sortMode, listedIDs(m), got)
+		}
+	}
+	if len(orders) < 2 {
+		t.Fatalf("no sort mode reordered the list: %v", orders)
+	}
+}
+
+func TestRefreshKeepsSelection(t *testing.T) {
+	m := testModel(t)
+	m.sortMode = SortStatus
+	m = runCmd(t, m, m.refreshIssues())
+	before := listedIDs(m)
+	m.selected = len(m.issues) - 1
+	id := m.selectedID()
+
+	// A status change elsewhere moves the selected issue in the status order
+	must(t, m.storage.UpdateIssueStatus(id, model.StatusClosed, ""))
+	must(t, m.storage.UpdateIssueStatus(id, model.StatusOpen, ""))
+	for _, other := range before {
+		if other != id {
+			must(t, m.storage.UpdateIssueStatus(other, model.StatusPlanned, ""))
+		}
+	}
+	m = runCmd(t, m, m.refreshIssues())
+	if after := listedIDs(m); strings.Join(after, ",") == strings.Join(before, ",") {
+		t.Fatalf("refresh didn't reorder the list: %v", after)
+	}
+	if got := m.selectedID(); got != id {
+		t.Errorf("after refresh selected %s (list %v), want %s", got, listedIDs(m), id)
+	}
+}
+
+func TestFilterChangeKeepsOrClampsSelection(t *testing.T) {
+	m := testModel(t)
+	m = press(t, m, "j", "j")
+	id := m.selectedID()
+
+	for range filterModeCount {
+		previous := m.selected
+		m = press(t, m, "f")
+		if slices.Contains(listedIDs(m), id) {
+			if got := m.selectedID(); got != id {
+				t.Errorf("filter %s lists %s but selected %s", m.filterMode, id, got)
+			}
+			continue
+		}
+		// The issue was filtered out: stay at the same position, within the list,
+		// and follow whichever issue is there from now on
+		want := max(0, min(previous, len(m.issues)-1))
+		if m.selected != want {
+			t.Errorf("filter %s: selection %d, want %d", m.filterMode, m.selected, want)
+		}
+		id = m.selectedID()
+	}
+}
+
+func TestDeleteNewIssueAfterEditorError(t *testing.T) {
+	m := testModel(t)
+	issue := mustCreate(t, m.storage, "Abandoned", model.TypeFeature, "")
+
+	m = update(t, m, syncAfterEditMsg{issueID: issue.ID, created: true, err: errors.New("exit status 1")})
+	if m.state != StateConfirm {
+		t.Fatalf("state %s after the editor error, want a confirm", m.state)
+	}
+	m = press(t, m, "y")
+	if want := "Deleted " + issue.ID; m.statusMsg != want {
+		t.Errorf("status = %q, want %q", m.statusMsg, want)
+	}
+	if slices.Contains(listedIDs(m), issue.ID) {
+		t.Errorf("%s still listed: %v", issue.ID, listedIDs(m))
+	}
+}
+
+func TestConfirmedActionsReportStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		keys []string
+		want string
+	}{
+		{"discard", []string{"d", "y"}, "Discarded "},
+		{"delete", []string{"D", "y"}, "Deleted "},
+		{"bulk delete", []string{"space", "space", "D", "y"}, "Deleted 2 issues"},
+		{"restore version", []string{"R", "V", "k", "r", "y"}, "Restored v1 as v3 for "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel(t)
+			m = press(t, m, tt.keys...)
+			if m.state != StateNormal || !strings.HasPrefix(m.statusMsg, tt.want) {
+				t.Errorf("state %s, status %q, want %q", m.state, m.statusMsg, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitStyleLabelScope(t *testing.T) {
+	m := testModel(t)
+	m.config.Commit.Scope = config.CommitScopeLabel
+
+	issue := &model.Issue{Type: model.TypeBug, Labels: []string{"storage", "tui"}}
+	if got := m.commitStyle(issue).Scope; got != "storage" {
+		t.Errorf("scope = %q, want the first label", got)
+	}
+	issue.Labels = nil
+	if got := m.commitStyle(issue).Scope; got != string(model.TypeBug) {
+		t.Errorf("scope without labels = %q, want the type", got)
+	}
+}
@@ -0,0 +1,50 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"runtime"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// openBrowser opens path with the platform's default handler without waiting for it
+func openBrowser(path string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path)
+	default:
+		cmd = exec.Command("xdg-open", path)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go func() { _ = cmd.Wait() }()
+	return nil
+}
+
+// openInBrowser renders markdown content to HTML and opens it in the default browser
+func (m Model) openInBrowser(kind, content string) (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	title := fmt.Sprintf("[%s] %s - %s", issue.ID, issue.Title, kind)
+	path, err := m.storage.RenderHTML(title, content)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Render failed: %v", err)
+		return m, nil
+	}
+
+	if err := openBrowser(path); err != nil {
+		m.statusMsg = fmt.Sprintf("No browser available - HTML saved to %s", path)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Opened %s %s in browser", issue.ID, kind)
+	return m, nil
+}
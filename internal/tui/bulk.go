@@ -0,0 +1,197 @@
+package tui
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/ui"
+)
+
+// bulkAction is a discard, delete or analyze waiting for confirmation over the
+// marked issues
+type bulkAction struct {
+	verb   string   // e.g. "discard", for prompts
+	done   string   // e.g. "Discarded", for the status message
+	ids    []string // marked issue IDs, in list order; nil means all marked issues
+	detail string   // optional note shown in the confirmation, e.g. a cost estimate
+	run    func(id string) error
+}
+
+// markPrefix returns the mark column shown before each list line while any issue
+// is marked, so marked and unmarked lines stay aligned
+func (m Model) markPrefix(issue *model.Issue) string {
+	if len(m.marked) == 0 {
+		return ""
+	}
+	if m.marked[issue.ID] {
+		return ui.IconMarked + " "
+	}
+	return "  "
+}
+
+// toggleMark marks or unmarks the selected issue and moves to the next one
+func (m Model) toggleMark() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+	if m.marked[issue.ID] {
+		delete(m.marked, issue.ID)
+	} else {
+		m.marked[issue.ID] = true
+	}
+	m.statusMsg = fmt.Sprintf("%d marked", len(m.marked))
+
+	if m.selected < len(m.issues)-1 {
+		m.selected++
+		m.ensureSelectedVisible(m.listVisibleItems())
+	}
+	m.calculateListMaxLineWidth()
+	return m, nil
+}
+
+// clearMarks unmarks every issue
+func (m Model) clearMarks() (Model, tea.Cmd) {
+	clear(m.marked)
+	m.calculateListMaxLineWidth()
+	m.statusMsg = "Marks cleared"
+	return m, nil
+}
+
+// pruneMarks drops marks on issues no longer in the list, so a bulk action never
+// touches an issue the user can't see
+func (m *Model) pruneMarks() {
+	visible := make(map[string]bool, len(m.issues))
+	for _, issue := range m.issues {
+		visible[issue.ID] = true
+	}
+	for id := range m.marked {
+		if !visible[id] {
+			delete(m.marked, id)
+		}
+	}
+}
+
+// markedIDs returns the marked issue IDs in list order
+func (m Model) markedIDs() []string {
+	var ids []string
+	for _, issue := range m.issues {
+		if m.marked[issue.ID] {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids
+}
+
+// confirmBulk asks before running action over the marked issues (or action.ids, if
+// set). Up to the configured threshold a y/n confirm is enough; above it the count
+// must be typed.
+func (m Model) confirmBulk(action bulkAction) (Model, tea.Cmd) {
+	if action.ids == nil {
+		action.ids = m.markedIDs()
+	}
+	n := len(action.ids)
+	detail := ""
+	if action.detail != "" {
+		detail = " - " + action.detail
+	}
+
+	threshold := m.config.UI.BulkConfirmThreshold
+	if threshold < 0 || n <= threshold {
+		m.state = StateConfirm
+		m.confirmMsg = fmt.Sprintf("%s%s %d marked issues (%s)%s?", strings.ToUpper(action.verb[:1]), action.verb[1:], n, strings.Join(action.ids, ", "), detail)
+		m.pendingBulk = &action
+		return m, nil
+	}
+
+	m.pendingBulk = &action
+	m.state = StateInput
+	m.inputMode = InputBulkConfirm
+	m.inputPrompt = fmt.Sprintf("Type %d to %s %d issues%s: ", n, action.verb, n, detail)
+	m.textInput.Focus()
+	return m, textinput.Blink
+}
+
+// executeBulkConfirm runs the pending bulk action if the typed count matches
+func (m Model) executeBulkConfirm(value string) (Model, tea.Cmd) {
+	action := m.pendingBulk
+	m.pendingBulk = nil
+	if action == nil {
+		return m, nil
+	}
+	if strings.TrimSpace(value) != strconv.Itoa(len(action.ids)) {
+		m.statusMsg = fmt.Sprintf("Count didn't match: nothing to %s", action.verb)
+		return m, nil
+	}
+	m.runBulk(*action)
+	return m, m.refreshIssues()
+}
+
+// runBulk applies action to each issue, clearing the marks of those it succeeded on
+func (m *Model) runBulk(action bulkAction) {
+	var failed []string
+	for _, id := range action.ids {
+		if err := action.run(id); err != nil {
+			failed = append(failed, fmt.Sprintf("%s (%v)", id, err))
+			continue
+		}
+		delete(m.marked, id)
+	}
+
+	done := len(action.ids) - len(failed)
+	m.statusMsg = fmt.Sprintf("%s %d issues", action.done, done)
+	if len(failed) > 0 {
+		m.statusMsg += fmt.Sprintf("; failed: %s", strings.Join(failed, ", "))
+	}
+}
+
+// confirmBulkAnalyze asks before analyzing the marked issues, showing how many will
+// run and an estimate of their tokens and cost. Closed issues and ones already
+// processing are skipped; existing analyses are redone.
+func (m Model) confirmBulkAnalyze() (Model, tea.Cmd) {
+	var ids []string
+	prompts := make(map[string]string)
+	skipped := 0
+	for _, issue := range m.issues {
+		if !m.marked[issue.ID] {
+			continue
+		}
+		m.processingLock.Lock()
+		_, busy := m.processing[issue.ID]
+		m.processingLock.Unlock()
+		brief, err := m.storage.LoadBrief(issue.ID)
+		if busy || issue.Status.IsClosed() || err != nil || brief == nil {
+			skipped++
+			continue
+		}
+		ids = append(ids, issue.ID)
+		prompts[issue.ID] = m.withLabelGuidance(claude.BuildAnalysisPromptJSON(brief.Content, m.storage.BriefPath(issue.ID), brief.Type), brief)
+	}
+	if len(ids) == 0 {
+		m.statusMsg = "None of the marked issues can be analyzed (closed or already processing)"
+		return m, nil
+	}
+
+	history, _ := m.storage.TotalUsage()
+	detail := claude.EstimateCalls(slices.Collect(maps.Values(prompts)), history).String()
+	if skipped > 0 {
+		detail += fmt.Sprintf("; skipping %d closed or busy", skipped)
+	}
+
+	return m.confirmBulk(bulkAction{verb: "analyze", done: "Started analyzing", ids: ids, detail: detail, run: func(id string) error {
+		m.processingLock.Lock()
+		m.processing[id] = "analyze"
+		m.processingLock.Unlock()
+		m.runStreaming(id, "analyze", prompts[id], "")
+		return nil
+	}})
+}
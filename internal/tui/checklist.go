@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// Checklist: "- [ ]" task items from tasks.md or the brief, toggled from an overlay.
+// Entered with [t] from the issue list.
+
+// taskProgress is a checklist's completion count
+type taskProgress struct {
+	done  int
+	total int
+}
+
+func (p taskProgress) String() string {
+	return fmt.Sprintf("%d/%d", p.done, p.total)
+}
+
+// checklistState holds the items shown in the checklist overlay
+type checklistState struct {
+	items  []model.ChecklistItem
+	cursor int
+}
+
+// loadTaskProgress returns checklist progress for issues that have a checklist
+func (m Model) loadTaskProgress(issues []*model.Issue) map[string]taskProgress {
+	progress := make(map[string]taskProgress)
+	for _, issue := range issues {
+		items, err := m.storage.LoadChecklist(issue.ID)
+		if err != nil || len(items) == 0 {
+			continue
+		}
+		done, total := model.ChecklistProgress(items)
+		progress[issue.ID] = taskProgress{done: done, total: total}
+	}
+	return progress
+}
+
+// openChecklist shows the selected issue's checklist
+func (m Model) openChecklist() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	items, err := m.storage.LoadChecklist(issue.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to load checklist: %v", err)
+		return m, nil
+	}
+	if len(items) == 0 {
+		m.statusMsg = fmt.Sprintf("%s has no checklist (add \"- [ ] item\" lines to brief.md or tasks.md)", issue.ID)
+		return m, nil
+	}
+
+	m.checklist = checklistState{items: items}
+	m.state = StateChecklist
+	return m, nil
+}
+
+// toggleChecklistItem flips the item under the cursor and writes the document back
+func (m Model) toggleChecklistItem() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil || m.checklist.cursor >= len(m.checklist.items) {
+		return m, nil
+	}
+
+	item := m.checklist.items[m.checklist.cursor]
+	if err := m.storage.ToggleChecklistItem(issue.ID, item.Line); err != nil {
+		m.statusMsg = fmt.Sprintf("Toggle failed: %v", err)
+		return m, nil
+	}
+
+	items, err := m.storage.LoadChecklist(issue.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to reload checklist: %v", err)
+		return m, nil
+	}
+	m.checklist.items = items
+	if m.checklist.cursor >= len(items) {
+		m.checklist.cursor = max(0, len(items)-1)
+	}
+
+	done, total := model.ChecklistProgress(items)
+	m.taskProgress[issue.ID] = taskProgress{done: done, total: total}
+	return m, nil
+}
+
+func (m Model) handleChecklistKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
+		m.checklist = checklistState{}
+		m.state = StateNormal
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.checklist.cursor > 0 {
+			m.checklist.cursor--
+		}
+	case "down", "j":
+		if m.checklist.cursor < len(m.checklist.items)-1 {
+			m.checklist.cursor++
+		}
+	case " ", "x", "enter":
+		return m.toggleChecklistItem()
+	case "t", "q":
+		m.checklist = checklistState{}
+		m.state = StateNormal
+	}
+	return m, nil
+}
+
+func (m Model) renderChecklistOverlay() string {
+	popupWidth := m.width - 10
+	if popupWidth < 60 {
+		popupWidth = 60
+	}
+	if popupWidth > 100 {
+		popupWidth = 100
+	}
+
+	done, total := model.ChecklistProgress(m.checklist.items)
+	title := fmt.Sprintf("Checklist %d/%d", done, total)
+	if issue := m.getSelectedIssue(); issue != nil {
+		title = fmt.Sprintf("Checklist [%s] %d/%d", issue.ID, done, total)
+	}
+
+	var lines []string
+	for i, item := range m.checklist.items {
+		box := "[ ]"
+		if item.Done {
+			box = "[x]"
+		}
+		label := fmt.Sprintf("%s%s %s", strings.Repeat("  ", item.Depth), box, item.Text)
+		if i == m.checklist.cursor {
+			lines = append(lines, OverlayStyles.Selected.Render("▸ "+label))
+		} else {
+			lines = append(lines, "  "+label)
+		}
+	}
+
+	footer := "[Space] Toggle    [Esc] Close    ↑↓ Select"
+	return m.renderBaseOverlay(title, strings.Join(lines, "\n"), footer, popupWidth)
+}
@@ -0,0 +1,35 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/atotto/clipboard"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// copyToClipboard writes text to the system clipboard
+func copyToClipboard(text string) error {
+	return clipboard.WriteAll(text)
+}
+
+// copyIssuePath copies the selected issue's absolute directory path to the clipboard
+func (m Model) copyIssuePath() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	path := m.storage.IssueDir(issue.ID)
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	if err := copyToClipboard(path); err != nil {
+		m.statusMsg = fmt.Sprintf("Clipboard unavailable: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Copied %s", path)
+	return m, nil
+}
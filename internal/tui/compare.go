@@ -0,0 +1,146 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Compare view: analysis and plan side by side, to check the plan follows the analysis.
+// Entered with [s] from either review preview and left with [s]/Esc back to that preview.
+
+// compareState holds the side-by-side viewports and which one has focus
+type compareState struct {
+	left      viewport.Model // analysis
+	right     viewport.Model // plan
+	focus     int            // 0 = left, 1 = right
+	returnTo  AppState       // preview state to return to
+	leftText  string
+	rightText string
+}
+
+// openCompare loads analysis and plan for the selected issue and enters the compare view
+func (m Model) openCompare() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	artifacts, err := m.storage.GetIssueWithArtifacts(issue.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to load %s: %v", issue.ID, err)
+		return m, nil
+	}
+
+	analysis := artifacts.Analysis
+	if analysis == "" && artifacts.AnalysisJSON != nil {
+		analysis = artifacts.AnalysisJSON.ToMarkdown()
+	}
+	if analysis == "" {
+		analysis = "(no analysis yet - press 'a' to analyze)"
+	}
+	plan := artifacts.Plan
+	if plan == "" {
+		plan = "(no plan yet - press 'p' to plan)"
+	}
+
+	m.compare.returnTo = m.state
+	m.compare.focus = 0
+	m.compare.leftText = analysis
+	m.compare.rightText = plan
+	m.sizeCompareViewports()
+	m.compare.left.GotoTop()
+	m.compare.right.GotoTop()
+
+	m.state = StateCompare
+	return m, nil
+}
+
+// sizeCompareViewports fits both panes to the terminal and re-wraps their content
+func (m *Model) sizeCompareViewports() {
+	paneWidth := (m.width-1)/2 - 2
+	if paneWidth < 10 {
+		paneWidth = 10
+	}
+	paneHeight := m.height - 4 // header, pane title, footer, status
+	if paneHeight < 3 {
+		paneHeight = 3
+	}
+
+	m.compare.left.Width = paneWidth
+	m.compare.left.Height = paneHeight
+	m.compare.left.SetContent(wrapText(m.compare.leftText, paneWidth))
+
+	m.compare.right.Width = paneWidth
+	m.compare.right.Height = paneHeight
+	m.compare.right.SetContent(wrapText(m.compare.rightText, paneWidth))
+}
+
+func (m Model) handleCompareKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
+		m.state = m.compare.returnTo
+		return m, nil
+	}
+
+	focused := &m.compare.left
+	if m.compare.focus == 1 {
+		focused = &m.compare.right
+	}
+
+	switch msg.String() {
+	case "s", "q":
+		m.state = m.compare.returnTo
+	case "tab", "left", "right", "h", "l":
+		m.compare.focus = 1 - m.compare.focus
+	case "up", "k":
+		focused.LineUp(1)
+	case "down", "j":
+		focused.LineDown(1)
+	case "pgup", "ctrl+u":
+		focused.HalfViewUp()
+	case "pgdown", "ctrl+d":
+		focused.HalfViewDown()
+	case "home", "g":
+		focused.GotoTop()
+	case "end", "G":
+		focused.GotoBottom()
+	}
+	return m, nil
+}
+
+func (m Model) renderCompareView() string {
+	headerText := "Analysis ↔ Plan"
+	if issue := m.getSelectedIssue(); issue != nil {
+		headerText = fmt.Sprintf("Analysis ↔ Plan [%s]", issue.ID)
+	}
+	header := m.styles.Header.Render(headerText)
+
+	paneTitle := func(title string, vp viewport.Model, focused bool) string {
+		text := fmt.Sprintf("%s %3.0f%%", title, vp.ScrollPercent()*100)
+		if focused {
+			return OptionSelectStyles.PanelTitle.UnsetMarginBottom().Render("▸ " + text)
+		}
+		return OverlayStyles.Hint.Render("  " + text)
+	}
+
+	paneWidth := m.compare.left.Width + 2
+	left := OptionSelectStyles.LeftPanel.
+		Width(paneWidth).
+		Render(paneTitle("Analysis", m.compare.left, m.compare.focus == 0) + "\n" + m.compare.left.View())
+	right := OptionSelectStyles.RightPanel.
+		Width(paneWidth).
+		Render(paneTitle("Plan", m.compare.right, m.compare.focus == 1) + "\n" + m.compare.right.View())
+
+	content := lipgloss.JoinHorizontal(lipgloss.Top, left, right)
+
+	footer := m.styles.Footer.Render("[Tab/←→] Switch pane  [↑↓] Scroll  [s/Esc] Back")
+	status := m.styles.StatusBar.Render(m.statusMsg)
+
+	view := lipgloss.JoinVertical(lipgloss.Left, header, content, footer, status)
+	return m.fitToHeight(strings.TrimRight(view, "\n"))
+}
@@ -0,0 +1,36 @@
+package tui
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultEditor is used when neither --editor nor $EDITOR names one
+const defaultEditor = "vim"
+
+// WithEditor overrides $EDITOR for this run (the --editor flag). The value may
+// include arguments, e.g. "code --wait".
+func (m Model) WithEditor(editor string) Model {
+	m.editor = editor
+	return m
+}
+
+// editorCommand returns the command that opens path in the editor: --editor, then
+// $EDITOR, then vim. It runs attached to the terminal.
+func (m Model) editorCommand(path string) *exec.Cmd {
+	editor := m.editor
+	if strings.TrimSpace(editor) == "" {
+		editor = os.Getenv("EDITOR")
+	}
+	fields := strings.Fields(editor)
+	if len(fields) == 0 {
+		fields = []string{defaultEditor}
+	}
+
+	cmd := exec.Command(fields[0], append(fields[1:], path)...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd
+}
@@ -0,0 +1,40 @@
+package tui
+
+import "github.com/lunit-heesungyang/issue-manager/internal/ui"
+
+// normalFooterHints are the list keys shown while no overlay is open
+const normalFooterHints = "[n]ew [a]nalyze [R]eview [p]lan [P]lan-review [i]mplement [u]pdate-log [c]lose [d]iscard [e]dit [f]ilter [s]ort [?] help [q]uit"
+
+// footerHints are the live keys of each overlay state. While an overlay is open the
+// footer shows these, dimmed, instead of normal-mode keys that wouldn't respond.
+var footerHints = map[AppState]string{
+	StateInput:            "[Enter] submit  [Esc] cancel",
+	StateConfirm:          "[y] yes  [n] no  [Esc] cancel",
+	StateTypeSelect:       "[f/b/r] type  [+/-] priority  [Esc] back to title",
+	StateReviewPreview:    "[e] edit  [f] feedback  [p] plan  [s] side-by-side  [V] versions  [d] diff  [M] raw  [w] wrap  [/] find  [Esc] close",
+	StatePlanPreview:      "[e] edit  [f] feedback  [i] implement  [s] side-by-side  [V] versions  [d] diff  [M] raw  [w] wrap  [/] find  [Esc] close",
+	StateCommitConfirm:    "[y] commit  [n] cancel",
+	StateCommitGenerating: "[Esc] cancel",
+	StateDetail:           "[e] edit  [Esc] close",
+	StateGitDiff:          "[Esc] close",
+	StateVersions:         "[Enter] view  [d] diff  [r] restore  [b] blame  [Esc] back",
+	StateVersionDiff:      "[Esc] back",
+	StateTemplateSelect:   "[Enter] create  [Esc] back",
+	StateChecklist:        "[Space] toggle  [Esc] close",
+	StateHelp:             "any key closes help",
+}
+
+// feedbackFooterHints replaces StateInput's hints while typing multi-line feedback
+const feedbackFooterHints = "[Ctrl+D] submit  [Enter] new line  [Esc] back"
+
+// renderFooter shows the keys that are live in the current state
+func (m Model) renderFooter() string {
+	hints, ok := footerHints[m.state]
+	if !ok {
+		return m.styles.Footer.Render(normalFooterHints)
+	}
+	if m.state == StateInput && (m.inputMode == InputReview || m.inputMode == InputPlanReview) {
+		hints = feedbackFooterHints
+	}
+	return m.styles.Footer.Foreground(ui.ColorMuted).Faint(true).Render(hints)
+}
@@ -0,0 +1,121 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Git diff: uncommitted changes to an issue's analysis and plan against HEAD, to
+// review hand edits of the generated documents before closing. Entered with [g]
+// from the issue list.
+
+// gitDiffFiles are the issue files the overlay diffs
+var gitDiffFiles = []string{"analysis.md", "plan.md"}
+
+// openGitDiff shows the selected issue's analysis.md and plan.md changes since HEAD
+func (m Model) openGitDiff() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+	if !m.storage.IsGitRepo() {
+		m.statusMsg = "Not a git repository: nothing to diff against"
+		return m, nil
+	}
+
+	var diffs []string
+	for _, file := range gitDiffFiles {
+		if diff := m.storage.IssueFileDiff(issue.ID, file); diff != "" {
+			diffs = append(diffs, strings.TrimRight(diff, "\n"))
+		}
+	}
+	if len(diffs) == 0 {
+		m.statusMsg = fmt.Sprintf("%s: %s unchanged since HEAD", issue.ID, strings.Join(gitDiffFiles, " and "))
+		return m, nil
+	}
+
+	m.sizeOverlayViewport()
+	m.detailContent = colorizeDiff(strings.Join(diffs, "\n\n"), m.viewport.Width)
+	m.viewport.SetContent(m.detailContent)
+	m.viewport.GotoTop()
+
+	m.state = StateGitDiff
+	return m, nil
+}
+
+// colorizeDiff wraps a unified diff to width, coloring each line (and its
+// continuation lines) by its prefix
+func colorizeDiff(diff string, width int) string {
+	var out []string
+	for _, line := range strings.Split(diff, "\n") {
+		style := diffLineStyle(line)
+		for _, wrapped := range strings.Split(wrapText(line, width), "\n") {
+			out = append(out, style.Render(wrapped))
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+// diffLineStyle picks the style of one unified diff line
+func diffLineStyle(line string) lipgloss.Style {
+	switch {
+	case strings.HasPrefix(line, "diff "), strings.HasPrefix(line, "index "),
+		strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "---"),
+		strings.HasPrefix(line, "new file"), strings.HasPrefix(line, "deleted file"):
+		return DiffStyles.Header
+	case strings.HasPrefix(line, "@@"):
+		return DiffStyles.Hunk
+	case strings.HasPrefix(line, "+"):
+		return DiffStyles.Added
+	case strings.HasPrefix(line, "-"):
+		return DiffStyles.Removed
+	}
+	return DiffStyles.Context
+}
+
+func (m Model) handleGitDiffKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
+		m.state = StateNormal
+		m.detailContent = ""
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		m.viewport.LineUp(1)
+	case "down", "j":
+		m.viewport.LineDown(1)
+	case "pgup", "ctrl+u":
+		m.viewport.HalfViewUp()
+	case "pgdown", "ctrl+d":
+		m.viewport.HalfViewDown()
+	case "home", "g":
+		m.viewport.GotoTop()
+	case "end", "G":
+		m.viewport.GotoBottom()
+	case "q":
+		m.state = StateNormal
+		m.detailContent = ""
+	}
+	return m, nil
+}
+
+func (m Model) renderGitDiffOverlay() string {
+	popupWidth := min(max(m.width-10, 60), 100)
+
+	title := "Changes since HEAD"
+	if issue := m.getSelectedIssue(); issue != nil {
+		title = fmt.Sprintf("Changes since HEAD [%s] %s", issue.ID, issue.Title)
+	}
+
+	scrollInfo := fmt.Sprintf(" %3.0f%% ", m.viewport.ScrollPercent()*100)
+	separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
+	content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, OverlayStyles.Hint.Render(scrollInfo))
+
+	return m.renderBaseOverlay(title, content, "[Esc] Close    ↑↓ Scroll", popupWidth)
+}
@@ -0,0 +1,156 @@
+package tui
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+// Full-text search: [/] in the issue list searches every brief, analysis and plan and
+// narrows the list to matching issues. A "re:" prefix makes the query a regex.
+// Esc clears the search.
+
+// regexPrefix marks a search query as a regular expression
+const regexPrefix = "re:"
+
+// listSearch is the active search narrowing the issue list
+type listSearch struct {
+	query   string
+	pattern *regexp.Regexp                    // case-insensitive, used for highlighting
+	matches map[string][]storage.SearchResult // by issue ID
+}
+
+func (s listSearch) active() bool {
+	return s.pattern != nil
+}
+
+// searchResultMsg delivers results of a background search
+type searchResultMsg struct {
+	query   string
+	pattern *regexp.Regexp
+	results []storage.SearchResult
+	err     error
+}
+
+// startSearch opens the search prompt, prefilled with the active query
+func (m Model) startSearch() (Model, tea.Cmd) {
+	m.state = StateInput
+	m.inputMode = InputSearch
+	m.inputPrompt = "Search (re: for regex): "
+	m.textInput.SetValue(m.search.query)
+	m.textInput.CursorEnd()
+	m.textInput.Focus()
+	return m, textinput.Blink
+}
+
+// runSearch searches in the background; an empty query clears the search
+func (m Model) runSearch(query string) (Model, tea.Cmd) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return m.clearSearch()
+	}
+
+	expr, useRegex := strings.CutPrefix(query, regexPrefix)
+	if !useRegex {
+		expr = regexp.QuoteMeta(query)
+	}
+	pattern, err := regexp.Compile("(?i)" + expr)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Invalid regex: %v", err)
+		return m, nil
+	}
+
+	m.statusMsg = fmt.Sprintf("Searching for %q...", query)
+	return m, func() tea.Msg {
+		results, err := m.storage.Search(expr, true)
+		return searchResultMsg{query: query, pattern: pattern, results: results, err: err}
+	}
+}
+
+// applySearchResults narrows the list to issues with matches
+func (m Model) applySearchResults(msg searchResultMsg) (Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Search failed: %v", msg.err)
+		return m, nil
+	}
+
+	matches := make(map[string][]storage.SearchResult)
+	for _, r := range msg.results {
+		matches[r.IssueID] = append(matches[r.IssueID], r)
+	}
+	m.search = listSearch{query: msg.query, pattern: msg.pattern, matches: matches}
+	m.listVOffset = 0
+	m.statusMsg = fmt.Sprintf("%d matches in %d issues for %q (Esc clears)", len(msg.results), len(matches), msg.query)
+	return m, m.refreshIssues()
+}
+
+// clearSearch restores the unfiltered list
+func (m Model) clearSearch() (Model, tea.Cmd) {
+	m.search = listSearch{}
+	m.statusMsg = "Search cleared"
+	return m, m.refreshIssues()
+}
+
+// filter keeps only issues the active search matched
+func (s listSearch) filter(issues []*model.Issue) []*model.Issue {
+	if !s.active() {
+		return issues
+	}
+	var filtered []*model.Issue
+	for _, issue := range issues {
+		if len(s.matches[issue.ID]) > 0 {
+			filtered = append(filtered, issue)
+		}
+	}
+	return filtered
+}
+
+// highlightStyled renders line in base, with pattern matches also reversed. Unlike
+// highlightLine it keeps base applied around each match, for styled list rows.
+func highlightStyled(line string, pattern *regexp.Regexp, base lipgloss.Style) string {
+	if pattern == nil {
+		return base.Render(line)
+	}
+	ranges := pattern.FindAllStringIndex(line, -1)
+	if len(ranges) == 0 {
+		return base.Render(line)
+	}
+
+	match := base.Reverse(true)
+	var sb strings.Builder
+	start := 0
+	for _, r := range ranges {
+		if r[0] == r[1] {
+			continue
+		}
+		sb.WriteString(base.Render(line[start:r[0]]))
+		sb.WriteString(match.Render(line[r[0]:r[1]]))
+		start = r[1]
+	}
+	sb.WriteString(base.Render(line[start:]))
+	return sb.String()
+}
+
+// searchMatchLines renders the selected issue's matches outside brief.md for the preview
+func (m Model) searchMatchLines(issueID string, width int) []string {
+	var lines []string
+	for _, r := range m.search.matches[issueID] {
+		if r.File == "brief.md" {
+			continue
+		}
+		line := fmt.Sprintf("%s:%d: %s", r.File, r.Line, r.Text)
+		if runewidth.StringWidth(line) > width {
+			line = runewidth.Truncate(line, width-3, "...")
+		}
+		lines = append(lines, highlightStyled(line, m.search.pattern, lipgloss.NewStyle()))
+	}
+	return lines
+}
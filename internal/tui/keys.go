@@ -9,7 +9,9 @@ type KeyMap struct {
 	New           key.Binding
 	Edit          key.Binding
 	Close         key.Binding
+	Reopen        key.Binding
 	Discard       key.Binding
+	Delete        key.Binding
 	Analyze       key.Binding
 	Plan          key.Binding
 	Review        key.Binding
@@ -17,7 +19,27 @@ type KeyMap struct {
 	Implement     key.Binding
 	UpdateLog     key.Binding
 	Refresh       key.Binding
+	RefreshOne    key.Binding
 	Filter        key.Binding
+	LabelFilter   key.Binding
+	Sort          key.Binding
+	ShrinkList    key.Binding
+	GrowList      key.Binding
+	Layout        key.Binding
+	Detail        key.Binding
+	GitDiff       key.Binding
+	Branch        key.Binding
+	Recent        key.Binding
+	Merge         key.Binding
+	CopyPath      key.Binding
+	Checklist     key.Binding
+	Search        key.Binding
+	Cancel        key.Binding
+	Mark          key.Binding
+	RawMarkdown   key.Binding
+	PriorityUp    key.Binding
+	PriorityDown  key.Binding
+	Help          key.Binding
 	Quit          key.Binding
 	Enter         key.Binding
 	Escape        key.Binding
@@ -26,6 +48,8 @@ type KeyMap struct {
 	AddOption     key.Binding
 	ConfirmOption key.Binding
 	SelectOption  key.Binding
+
+	SubmitFeedback key.Binding
 }
 
 // DefaultKeyMap returns the default key bindings
@@ -51,10 +75,18 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("c"),
 			key.WithHelp("c", "close"),
 		),
+		Reopen: key.NewBinding(
+			key.WithKeys("O"),
+			key.WithHelp("O", "reopen last closed"),
+		),
 		Discard: key.NewBinding(
 			key.WithKeys("d"),
 			key.WithHelp("d", "discard"),
 		),
+		Delete: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "delete"),
+		),
 		Analyze: key.NewBinding(
 			key.WithKeys("a"),
 			key.WithHelp("a", "analyze"),
@@ -83,10 +115,90 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
 		),
+		RefreshOne: key.NewBinding(
+			key.WithKeys("ctrl+r"),
+			key.WithHelp("ctrl+r", "refresh issue"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("f"),
 			key.WithHelp("f", "filter"),
 		),
+		LabelFilter: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "filter label"),
+		),
+		Sort: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "sort"),
+		),
+		ShrinkList: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "shrink list"),
+		),
+		GrowList: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "grow list"),
+		),
+		Layout: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "layout"),
+		),
+		Detail: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open detail"),
+		),
+		GitDiff: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "diff vs HEAD"),
+		),
+		Branch: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "issue branch"),
+		),
+		Merge: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "merge into"),
+		),
+		Recent: key.NewBinding(
+			key.WithKeys("ctrl+o"),
+			key.WithHelp("ctrl+o", "recent issues"),
+		),
+		CopyPath: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy path"),
+		),
+		Checklist: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "checklist"),
+		),
+		Search: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "search"),
+		),
+		Cancel: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "cancel task"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		RawMarkdown: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "raw/rendered markdown"),
+		),
+		PriorityUp: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", "raise priority"),
+		),
+		PriorityDown: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "lower priority"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "help"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -115,6 +227,10 @@ func DefaultKeyMap() KeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "select"),
 		),
+		SubmitFeedback: key.NewBinding(
+			key.WithKeys("ctrl+d"),
+			key.WithHelp("ctrl+d", "submit feedback"),
+		),
 	}
 }
 
@@ -126,9 +242,10 @@ func (k KeyMap) ShortHelp() []key.Binding {
 // FullHelp returns keybindings for the expanded help view
 func (k KeyMap) FullHelp() [][]key.Binding {
 	return [][]key.Binding{
-		{k.Up, k.Down, k.New, k.Edit},
-		{k.Analyze, k.Plan, k.Review, k.PlanReview},
-		{k.Implement, k.UpdateLog, k.Close, k.Discard},
-		{k.Filter, k.Refresh, k.Quit},
+		{k.Up, k.Down, k.Recent, k.New, k.Edit},
+		{k.Analyze, k.Plan, k.Review, k.PlanReview, k.PriorityUp, k.PriorityDown},
+		{k.Implement, k.UpdateLog, k.Cancel, k.Close, k.Reopen, k.Mark, k.Discard, k.Delete, k.Merge},
+		{k.Filter, k.Sort, k.LabelFilter, k.Search, k.Refresh, k.RefreshOne, k.ShrinkList, k.GrowList},
+		{k.Layout, k.Detail, k.GitDiff, k.Branch, k.RawMarkdown, k.Checklist, k.CopyPath, k.Help, k.Quit},
 	}
 }
@@ -0,0 +1,73 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/claude"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// maxLabelBadgesWidth caps the label badges on a list line so titles stay readable
+const maxLabelBadgesWidth = 24
+
+// labelBadges renders labels as " [auth] [ui]", truncated to maxLabelBadgesWidth cells
+func labelBadges(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+	badges := make([]string, len(labels))
+	for i, label := range labels {
+		badges[i] = "[" + label + "]"
+	}
+	return " " + runewidth.Truncate(strings.Join(badges, " "), maxLabelBadgesWidth, "…")
+}
+
+// filterByLabel keeps the issues carrying label; an empty label keeps all
+func filterByLabel(issues []*model.Issue, label string) []*model.Issue {
+	if label == "" {
+		return issues
+	}
+	var result []*model.Issue
+	for _, issue := range issues {
+		if issue.HasLabel(label) {
+			result = append(result, issue)
+		}
+	}
+	return result
+}
+
+// cycleLabelFilter steps the label filter through every label in the index, then
+// back to showing all issues
+func (m Model) cycleLabelFilter() (Model, tea.Cmd) {
+	if len(m.labels) == 0 {
+		m.labelFilter = ""
+		m.statusMsg = "No issues have labels"
+		return m, nil
+	}
+
+	next := 0
+	if i := slices.Index(m.labels, m.labelFilter); i >= 0 {
+		next = i + 1
+	}
+	if next < len(m.labels) {
+		m.labelFilter = m.labels[next]
+		m.statusMsg = fmt.Sprintf("Label: %s", m.labelFilter)
+	} else {
+		m.labelFilter = ""
+		m.statusMsg = "Label: all"
+	}
+
+	m.listVOffset = 0
+	return m, m.refreshIssues()
+}
+
+// withLabelGuidance appends the configured guidance for the issue's labels to an
+// analysis or plan prompt
+func (m Model) withLabelGuidance(prompt string, issue *model.Issue) string {
+	return claude.WithLabelGuidance(prompt, issue.Labels, m.config.Claude.LabelGuidance)
+}
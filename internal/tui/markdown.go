@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/glamour"
+	"github.com/charmbracelet/glamour/styles"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// markdownCache renders markdown with glamour, keeping the last rendering of each
+// document so unchanged content isn't re-rendered on every frame
+type markdownCache struct {
+	style   string
+	entries map[string]renderedMarkdown // by document key, e.g. "brief/<id>"
+}
+
+type renderedMarkdown struct {
+	source string
+	width  int
+	output string
+}
+
+// newMarkdownCache picks the glamour style once, before the program owns the terminal
+func newMarkdownCache(ascii bool) *markdownCache {
+	style := styles.LightStyle
+	switch {
+	case ascii:
+		style = styles.AsciiStyle
+	case lipgloss.HasDarkBackground():
+		style = styles.DarkStyle
+	}
+	return &markdownCache{style: style, entries: make(map[string]renderedMarkdown)}
+}
+
+// render returns source rendered for width, or source itself if glamour fails
+func (c *markdownCache) render(key, source string, width int) string {
+	if e, ok := c.entries[key]; ok && e.width == width && e.source == source {
+		return e.output
+	}
+
+	output := source
+	r, err := glamour.NewTermRenderer(
+		glamour.WithStandardStyle(c.style),
+		glamour.WithColorProfile(lipgloss.ColorProfile()),
+		glamour.WithWordWrap(width),
+	)
+	if err == nil {
+		if rendered, err := r.Render(source); err == nil {
+			output = trimBlankLines(rendered)
+		}
+	}
+	c.entries[key] = renderedMarkdown{source: source, width: width, output: output}
+	return output
+}
+
+// trimBlankLines drops the empty lines glamour pads its output with
+func trimBlankLines(s string) string {
+	lines := strings.Split(s, "\n")
+	isBlank := func(line string) bool { return strings.TrimSpace(ansi.Strip(line)) == "" }
+	for len(lines) > 0 && isBlank(lines[0]) {
+		lines = lines[1:]
+	}
+	for len(lines) > 0 && isBlank(lines[len(lines)-1]) {
+		lines = lines[:len(lines)-1]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// previewDoc names the document in the current review preview
+func (m Model) previewDoc() string {
+	if m.state == StatePlanPreview {
+		return "plan"
+	}
+	return "analysis"
+}
+
+// previewText returns the review preview document as displayed: rendered
+// markdown unless the raw view is on, with long lines wrapped in wrap mode
+func (m Model) previewText() string {
+	raw := m.previewSource()
+	if m.rawMarkdown {
+		if m.wrapPreview {
+			return wrapText(raw, m.viewport.Width)
+		}
+		return raw
+	}
+	key := m.previewDoc()
+	if issue := m.getSelectedIssue(); issue != nil {
+		key += "/" + issue.ID
+	}
+	rendered := m.markdown.render(key, raw, m.viewport.Width)
+	if m.wrapPreview {
+		// Glamour wraps prose already; this catches code blocks and tables
+		return ansi.Hardwrap(rendered, m.viewport.Width, true)
+	}
+	return rendered
+}
+
+// reloadPreviewText redisplays the review preview from the start of its lines,
+// e.g. after switching between the raw and rendered views
+func (m *Model) reloadPreviewText() {
+	m.hOffset = 0
+	text := m.previewText()
+	m.maxLineWidth = renderedLineWidth(text)
+	if m.find.query != "" {
+		m.updateFind()
+		return
+	}
+	m.setPreviewContent(text)
+}
+
+// toggleRawMarkdown switches the preview panel and review overlays between
+// rendered and raw markdown
+func (m Model) toggleRawMarkdown() (Model, tea.Cmd) {
+	m.rawMarkdown = !m.rawMarkdown
+	if m.state == StateReviewPreview || m.state == StatePlanPreview {
+		m.reloadPreviewText()
+	}
+	if m.rawMarkdown {
+		m.statusMsg = "Showing raw markdown"
+	} else {
+		m.statusMsg = "Showing rendered markdown"
+	}
+	return m, nil
+}
+
+// toggleWrapPreview switches the review overlays between wrapping long lines and
+// panning them with h/l, and remembers the choice
+func (m Model) toggleWrapPreview() (Model, tea.Cmd) {
+	m.wrapPreview = !m.wrapPreview
+	m.prefs.WrapPreview = m.wrapPreview
+	_ = m.prefs.Save()
+
+	m.reloadPreviewText()
+	if m.wrapPreview {
+		m.statusMsg = "Wrapping long lines"
+	} else {
+		m.statusMsg = "Panning long lines (h/l)"
+	}
+	return m, nil
+}
+
+// renderedLineWidth returns the widest line's display width, ignoring escape codes
+func renderedLineWidth(content string) int {
+	widest := 0
+	for _, line := range strings.Split(content, "\n") {
+		widest = max(widest, ansi.StringWidth(line))
+	}
+	return widest
+}
+
+// cutRenderedLines is applyHorizontalOffset plus highlightMatches for styled
+// text. Lines matching query lose their styling so the match can be highlighted.
+func cutRenderedLines(content string, offset, width int, query string) string {
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		if query != "" {
+			if plain := ansi.Strip(line); containsFold(plain, query) {
+				line = highlightLine(plain, query)
+			}
+		}
+		lines[i] = ansi.Cut(line, offset, offset+width)
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,307 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/config"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+// testModel returns a sized model over a fresh project with its list loaded:
+//
+//	0001 planned bug with two analysis versions, a plan and a checklist
+//	0002 open feature
+//	0003 analyzed refactor with a structured analysis awaiting an option
+func testModel(t *testing.T) Model {
+	t.Helper()
+	// Keep the persisted TUI state and editor away from the user's
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", home)
+	t.Setenv("EDITOR", "true")
+
+	dir := t.TempDir()
+	s := storage.New(dir, "")
+	if err := s.EnsureIssuesDir(); err != nil {
+		t.Fatal(err)
+	}
+	planned := mustCreate(t, s, "Planned bug", model.TypeBug, "Body\n\n- [ ] first step\n- [ ] second step")
+	mustCreate(t, s, "Open feature", model.TypeFeature, "Second body")
+	analyzed := mustCreate(t, s, "Structured refactor", model.TypeRefactor, "Third body")
+
+	must(t, s.SaveAnalysis(planned.ID, "## Summary\nfirst analysis\n## Options\nfoo"))
+	if _, err := s.SaveAnalysisRevision(planned.ID, "## Summary\nrevised analysis\n## Options\nfoo"); err != nil {
+		t.Fatal(err)
+	}
+	must(t, s.SavePlan(planned.ID, "## Plan Summary\n- step\n"))
+	must(t, s.UpdateIssueStatus(planned.ID, model.StatusPlanned, ""))
+	must(t, s.SaveAnalysisJSON(analyzed.ID, &model.Analysis{
+		Summary: "structured",
+		Options: []model.AnalysisOption{{ID: "A", Title: "Option A"}, {ID: "B", Title: "Option B"}},
+	}))
+	must(t, s.UpdateIssueStatus(analyzed.ID, model.StatusAnalyzed, ""))
+
+	cfg := config.Default()
+	cfg.Claude.Command = "false" // nothing here should reach Claude
+	m := New(dir, "", cfg)
+	m = update(t, m, tea.WindowSizeMsg{Width: 120, Height: 40})
+	m = runCmd(t, m, m.refreshIssues())
+	if got := len(m.issues); got != 3 {
+		t.Fatalf("loaded %d issues, want 3", got)
+	}
+	return m
+}
+
+func mustCreate(t *testing.T, s *storage.Storage, title string, issueType model.IssueType, body string) *model.Issue {
+	t.Helper()
+	issue, err := s.CreateIssue(title, issueType, model.PriorityMedium, body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return issue
+}
+
+func must(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+// update feeds msg to m and runs the commands it returns
+func update(t *testing.T, m Model, msg tea.Msg) Model {
+	t.Helper()
+	next, cmd := m.Update(msg)
+	return runCmd(t, next.(Model), cmd)
+}
+
+// runCmd runs cmd as the Bubble Tea runtime would and feeds its messages back in.
+// Timers and cursor blinks are dropped, and a command still blocked after a moment
+// (e.g. waiting for a Claude result) is abandoned.
+func runCmd(t *testing.T, m Model, cmd tea.Cmd) Model {
+	t.Helper()
+	if cmd == nil {
+		return m
+	}
+	done := make(chan tea.Msg, 1)
+	go func() { done <- cmd() }()
+
+	var msg tea.Msg
+	select {
+	case msg = <-done:
+	case <-time.After(200 * time.Millisecond):
+		return m
+	}
+
+	switch msg := msg.(type) {
+	case nil, tea.QuitMsg, tickMsg:
+		return m
+	case tea.BatchMsg:
+		for _, c := range msg {
+			m = runCmd(t, m, c)
+		}
+		return m
+	}
+	if strings.Contains(strings.ToLower(fmt.Sprintf("%T", msg)), "blink") {
+		return m
+	}
+	return update(t, m, msg)
+}
+
+// keyMsg converts a key name as written in the tests into a key press
+func keyMsg(name string) tea.KeyMsg {
+	switch name {
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "space":
+		return tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}}
+	case "ctrl+o":
+		return tea.KeyMsg{Type: tea.KeyCtrlO}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(name)}
+}
+
+// press sends each key in turn
+func press(t *testing.T, m Model, keys ...string) Model {
+	t.Helper()
+	for _, k := range keys {
+		m = update(t, m, keyMsg(k))
+	}
+	return m
+}
+
+func (s AppState) String() string {
+	names := map[AppState]string{
+		StateNormal: "Normal", StateInput: "Input", StateConfirm: "Confirm",
+		StateTypeSelect: "TypeSelect", StateReviewPreview: "ReviewPreview",
+		StatePlanPreview: "PlanPreview", StateCommitConfirm: "CommitConfirm",
+		StateCommitGenerating: "CommitGenerating", StateOptionSelect: "OptionSelect",
+		StateDetail: "Detail", StateCompare: "Compare", StateVersions: "Versions",
+		StateTemplateSelect: "TemplateSelect", StateChecklist: "Checklist",
+		StateHelp: "Help", StateGitDiff: "GitDiff", StateVersionDiff: "VersionDiff",
+	}
+	if name, ok := names[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("AppState(%d)", int(s))
+}
+
+// TestEscBacksOutOneLevel drives the edges of the navigation graph documented on
+// AppState: keys into each state, then Esc, one level at a time
+func TestEscBacksOutOneLevel(t *testing.T) {
+	type step struct {
+		keys  []string
+		state AppState
+	}
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{"new issue title", []step{
+			{[]string{"n"}, StateInput},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"type select back to title", []step{
+			{[]string{"n", "x", "enter"}, StateTypeSelect},
+			{[]string{"esc"}, StateInput},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"analysis review", []step{
+			{[]string{"R"}, StateReviewPreview},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"review feedback", []step{
+			{[]string{"R", "f"}, StateInput},
+			{[]string{"esc"}, StateReviewPreview},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"plan review feedback", []step{
+			{[]string{"P", "f"}, StateInput},
+			{[]string{"esc"}, StatePlanPreview},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"find clears before closing", []step{
+			{[]string{"R", "/", "S", "enter"}, StateReviewPreview},
+			{[]string{"esc"}, StateReviewPreview},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"side by side", []step{
+			{[]string{"R", "s"}, StateCompare},
+			{[]string{"esc"}, StateReviewPreview},
+		}},
+		{"versions", []step{
+			{[]string{"R", "V"}, StateVersions},
+			{[]string{"esc"}, StateReviewPreview},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"viewing a version", []step{
+			{[]string{"R", "V", "enter"}, StateVersions},
+			{[]string{"esc"}, StateVersions},
+			{[]string{"esc"}, StateReviewPreview},
+		}},
+		{"version diff from the preview", []step{
+			{[]string{"R", "d"}, StateVersionDiff},
+			{[]string{"esc"}, StateReviewPreview},
+		}},
+		{"version diff from the version list", []step{
+			{[]string{"R", "V", "d"}, StateVersionDiff},
+			{[]string{"esc"}, StateVersions},
+		}},
+		{"option select", []step{
+			{[]string{"j", "j", "R"}, StateOptionSelect},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"confirm", []step{
+			{[]string{"d"}, StateConfirm},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"search", []step{
+			{[]string{"/"}, StateInput},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"detail", []step{
+			{[]string{"o"}, StateDetail},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"checklist", []step{
+			{[]string{"t"}, StateChecklist},
+			{[]string{"esc"}, StateNormal},
+		}},
+		{"help closes on any key", []step{
+			{[]string{"?"}, StateHelp},
+			{[]string{"x"}, StateNormal},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := testModel(t)
+			for _, step := range tt.steps {
+				m = press(t, m, step.keys...)
+				if m.state != step.state {
+					t.Fatalf("after %v: state %s, want %s (status %q)", step.keys, m.state, step.state, m.statusMsg)
+				}
+			}
+		})
+	}
+}
+
+func TestEscFromTypeSelectKeepsTitle(t *testing.T) {
+	m := testModel(t)
+	m = press(t, m, "n", "T", "i", "t", "l", "e", "enter", "esc")
+	if m.state != StateInput {
+		t.Fatalf("state %s, want Input", m.state)
+	}
+	if got := m.textInput.Value(); got != "Title" {
+		t.Errorf("title = %q after Esc, want it kept", got)
+	}
+}
+
+func TestEscFromTemplateSelectBacksToTypeSelect(t *testing.T) {
+	m := testModel(t)
+	templates := filepath.Join(m.storage.IssuesDir, ".templates")
+	must(t, os.MkdirAll(templates, 0755))
+	must(t, os.WriteFile(filepath.Join(templates, "security.md"), []byte("## Threat\n"), 0644))
+
+	m = press(t, m, "n", "x", "enter", "b")
+	if m.state != StateTemplateSelect {
+		t.Fatalf("state %s, want TemplateSelect", m.state)
+	}
+	m = press(t, m, "esc")
+	if m.state != StateTypeSelect {
+		t.Fatalf("state %s after Esc, want TypeSelect", m.state)
+	}
+}
+
+func TestEscCancelsConfirmWithoutActing(t *testing.T) {
+	m := testModel(t)
+	id := m.selectedID()
+	m = press(t, m, "d", "esc")
+	issue, err := m.storage.LoadBrief(id)
+	must(t, err)
+	if issue.Status == model.StatusInvalid {
+		t.Error("Esc on the discard confirmation discarded the issue")
+	}
+}
+
+func TestEscClearsMarks(t *testing.T) {
+	m := testModel(t)
+	m = press(t, m, "space", "space")
+	if len(m.marked) != 2 {
+		t.Fatalf("marked %d issues, want 2", len(m.marked))
+	}
+	m = press(t, m, "esc")
+	if len(m.marked) != 0 || m.state != StateNormal {
+		t.Errorf("after Esc: %d marks, state %s; want none and Normal", len(m.marked), m.state)
+	}
+}
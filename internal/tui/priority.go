@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// bumpPriority moves the selected issue's priority delta steps and stages the change
+func (m Model) bumpPriority(delta int) (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	priority := issue.Priority.Bump(delta)
+	if priority == issue.Priority {
+		m.statusMsg = fmt.Sprintf("%s is already %s priority", issue.ID, priority)
+		return m, nil
+	}
+
+	if err := m.storage.UpdateIssuePriority(issue.ID, priority); err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("%s priority: %s → %s", issue.ID, issue.Priority, priority)
+	return m, m.refreshIssues()
+}
@@ -0,0 +1,93 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+// Recently viewed issues: Ctrl+O steps back through the issues worked on, like a
+// browser's back button, to bounce between related issues without scrolling. An
+// issue counts as viewed once a key other than list movement is pressed on it.
+
+// maxRecentIssues bounds the history
+const maxRecentIssues = 10
+
+// recentIssues is the viewed-issue history, oldest first
+type recentIssues struct {
+	ids    []string
+	cursor int // index of the issue Ctrl+O last jumped to; -1 outside a walk
+}
+
+func newRecentIssues(ids []string) recentIssues {
+	if len(ids) > maxRecentIssues {
+		ids = ids[len(ids)-maxRecentIssues:]
+	}
+	return recentIssues{ids: slices.Clone(ids), cursor: -1}
+}
+
+// visit moves id to the newest end of the history and ends any Ctrl+O walk
+func (r *recentIssues) visit(id string) {
+	r.cursor = -1
+	if id == "" {
+		return
+	}
+	r.ids = slices.DeleteFunc(r.ids, func(other string) bool { return other == id })
+	r.ids = append(r.ids, id)
+	if len(r.ids) > maxRecentIssues {
+		r.ids = r.ids[len(r.ids)-maxRecentIssues:]
+	}
+}
+
+// jumpRecent selects the next older recently viewed issue in the list, wrapping
+// around to the newest, and skipping issues the current filter hides
+func (m Model) jumpRecent() (Model, tea.Cmd) {
+	current := m.selectedID()
+	if m.recent.cursor < 0 {
+		// Starting a walk: the issue being left is where Ctrl+O wraps back to
+		m.recent.visit(current)
+		m.recent.cursor = len(m.recent.ids) - 1
+	}
+
+	n := len(m.recent.ids)
+	for step := 1; step < n; step++ {
+		i := (m.recent.cursor - step + n) % n
+		id := m.recent.ids[i]
+		index := slices.IndexFunc(m.issues, func(issue *model.Issue) bool { return issue.ID == id })
+		if id == current || index < 0 {
+			continue
+		}
+		m.recent.cursor = i
+		m.selected = index
+		m.ensureSelectedVisible(m.listVisibleItems())
+		m.statusMsg = fmt.Sprintf("Recent: %s (%d back of %d)", id, n-1-i, n-1)
+		return m, nil
+	}
+	m.statusMsg = "No other recently viewed issues in this list"
+	return m, nil
+}
+
+// recentKeyFor identifies a project's history in the persisted state
+func recentKeyFor(s *storage.Storage) string {
+	if dir, err := filepath.Abs(s.IssuesDir); err == nil {
+		return dir
+	}
+	return s.IssuesDir
+}
+
+// saveRecent persists the history for the next session
+func (m Model) saveRecent() {
+	if len(m.recent.ids) == 0 {
+		return
+	}
+	if m.prefs.Recent == nil {
+		m.prefs.Recent = make(map[string][]string)
+	}
+	m.prefs.Recent[recentKeyFor(m.storage)] = m.recent.ids
+	_ = m.prefs.Save()
+}
@@ -0,0 +1,29 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// reopenLastClosed reopens the issue closed most recently, for "closed that too early"
+func (m Model) reopenLastClosed() (Model, tea.Cmd) {
+	idx, err := m.storage.LoadIndex()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	issue := idx.LastClosed()
+	if issue == nil {
+		m.statusMsg = "No recently closed issue to reopen"
+		return m, nil
+	}
+
+	status, err := m.storage.ReopenIssue(issue.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Error: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Reopened %s as %s: %s", issue.ID, status, issue.Title)
+	return m, m.refreshIssues()
+}
@@ -0,0 +1,188 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Find-in-document for the analysis/plan review previews: [/] starts typing a query,
+// matches are highlighted as you type, [n]/[N] jump between matching lines.
+
+// highlightStyle marks query matches in rendered content
+var highlightStyle = lipgloss.NewStyle().Reverse(true)
+
+// findState holds the query and the lines it matches in the current preview
+type findState struct {
+	query   string
+	typing  bool
+	matches []int // line indexes containing the query
+	current int   // index into matches
+}
+
+// foldPrefixLen returns the byte length of the prefix of s that case-insensitively
+// equals query, or -1 if s does not start with query
+func foldPrefixLen(s, query string) int {
+	n := 0
+	for _, qr := range query {
+		if n >= len(s) {
+			return -1
+		}
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !strings.EqualFold(string(r), string(qr)) {
+			return -1
+		}
+		n += size
+	}
+	return n
+}
+
+// containsFold reports whether s contains query, ignoring case
+func containsFold(s, query string) bool {
+	for i := range s {
+		if foldPrefixLen(s[i:], query) >= 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// highlightMatches wraps case-insensitive occurrences of query in the highlight style.
+// Apply it after horizontal offset/truncation, which measure plain display columns.
+func highlightMatches(content, query string) string {
+	if query == "" {
+		return content
+	}
+
+	lines := strings.Split(content, "\n")
+	for i, line := range lines {
+		lines[i] = highlightLine(line, query)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// highlightLine highlights every match of query within a single line
+func highlightLine(line, query string) string {
+	var sb strings.Builder
+	start := 0
+	for i := 0; i < len(line); {
+		if n := foldPrefixLen(line[i:], query); n > 0 {
+			sb.WriteString(line[start:i])
+			sb.WriteString(highlightStyle.Render(line[i : i+n]))
+			i += n
+			start = i
+			continue
+		}
+		_, size := utf8.DecodeRuneInString(line[i:])
+		i += size
+	}
+	if start == 0 {
+		return line
+	}
+	sb.WriteString(line[start:])
+	return sb.String()
+}
+
+// matchingLines returns the indexes of lines containing query
+func matchingLines(content, query string) []int {
+	if query == "" {
+		return nil
+	}
+	var matches []int
+	for i, line := range strings.Split(content, "\n") {
+		if containsFold(line, query) {
+			matches = append(matches, i)
+		}
+	}
+	return matches
+}
+
+// previewSource returns the raw document shown in the current review preview
+func (m Model) previewSource() string {
+	if m.state == StatePlanPreview {
+		return m.reviewPlan
+	}
+	return m.reviewAnalysis
+}
+
+// setPreviewContent re-renders the preview viewport with the current horizontal
+// offset and find highlights, keeping the scroll position
+func (m *Model) setPreviewContent(text string) {
+	yOffset := m.viewport.YOffset
+	if m.rawMarkdown {
+		content := applyHorizontalOffset(text, m.hOffset, m.viewport.Width)
+		m.viewport.SetContent(highlightMatches(content, m.find.query))
+	} else {
+		m.viewport.SetContent(cutRenderedLines(text, m.hOffset, m.viewport.Width, m.find.query))
+	}
+	m.viewport.SetYOffset(yOffset)
+}
+
+// updateFind recomputes matches for the current query and scrolls to the first one
+func (m *Model) updateFind() {
+	text := m.previewText()
+	m.find.matches = matchingLines(ansi.Strip(text), m.find.query)
+	m.find.current = 0
+	m.setPreviewContent(text)
+	if len(m.find.matches) > 0 {
+		m.viewport.SetYOffset(m.find.matches[0])
+	}
+}
+
+// jumpToMatch moves to the next (delta=1) or previous (delta=-1) matching line
+func (m *Model) jumpToMatch(delta int) {
+	if len(m.find.matches) == 0 {
+		return
+	}
+	n := len(m.find.matches)
+	m.find.current = (m.find.current + delta + n) % n
+	m.viewport.SetYOffset(m.find.matches[m.find.current])
+}
+
+// clearFind drops the query and its highlights
+func (m *Model) clearFind() {
+	m.find = findState{}
+	m.setPreviewContent(m.previewText())
+}
+
+// handleFindKey handles typing a query; matches update on every keystroke
+func (m Model) handleFindKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case key.Matches(msg, m.keys.Escape):
+		m.clearFind()
+	case msg.Type == tea.KeyEnter:
+		m.find.typing = false
+		if m.find.query == "" {
+			m.clearFind()
+		}
+	case msg.Type == tea.KeyBackspace:
+		if m.find.query != "" {
+			_, size := utf8.DecodeLastRuneInString(m.find.query)
+			m.find.query = m.find.query[:len(m.find.query)-size]
+			m.updateFind()
+		}
+	case msg.Type == tea.KeyRunes, msg.Type == tea.KeySpace:
+		m.find.query += string(msg.Runes)
+		m.updateFind()
+	}
+	return m, nil
+}
+
+// findStatus renders the query and match position for the preview's scroll line
+func (m Model) findStatus() string {
+	if m.find.typing {
+		return fmt.Sprintf(" /%s█ ", m.find.query)
+	}
+	if m.find.query == "" {
+		return ""
+	}
+	if len(m.find.matches) == 0 {
+		return fmt.Sprintf(" /%s (no match) ", m.find.query)
+	}
+	return fmt.Sprintf(" /%s (%d/%d) ", m.find.query, m.find.current+1, len(m.find.matches))
+}
@@ -0,0 +1,55 @@
+package tui
+
+import (
+	"slices"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// SortMode is the order of the issue list
+type SortMode int
+
+const (
+	SortID SortMode = iota
+	SortCreated
+	SortUpdated
+	SortStatus
+	SortPriority
+
+	sortModeCount
+)
+
+func (s SortMode) String() string {
+	switch s {
+	case SortID:
+		return "ID"
+	case SortCreated:
+		return "created"
+	case SortUpdated:
+		return "updated"
+	case SortStatus:
+		return "status"
+	case SortPriority:
+		return "priority"
+	}
+	return ""
+}
+
+// sortIssues returns issues in mode's order. The slice is copied first, since it
+// may be the storage's cached index.
+func sortIssues(issues []*model.Issue, mode SortMode) []*model.Issue {
+	idx := &model.IssueIndex{Issues: slices.Clone(issues)}
+	switch mode {
+	case SortID:
+		idx.SortByID()
+	case SortCreated:
+		idx.SortByCreated()
+	case SortUpdated:
+		idx.SortByUpdated()
+	case SortStatus:
+		idx.SortByStatus()
+	case SortPriority:
+		idx.SortByPriority()
+	}
+	return idx.Issues
+}
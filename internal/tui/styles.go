@@ -2,6 +2,7 @@ package tui
 
 import (
 	"github.com/charmbracelet/lipgloss"
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
 	"github.com/lunit-heesungyang/issue-manager/internal/ui"
 )
 
@@ -21,6 +22,16 @@ type Styles struct {
 	SelectedItem   lipgloss.Style
 	NormalItem     lipgloss.Style
 	ProcessingItem lipgloss.Style
+	QueuedItem     lipgloss.Style
+	ListSummary    lipgloss.Style
+
+	// Status colors for list rows
+	StatusOpen        lipgloss.Style
+	StatusAnalyzed    lipgloss.Style
+	StatusPlanned     lipgloss.Style
+	StatusImplemented lipgloss.Style
+	StatusClosed      lipgloss.Style
+	StatusInvalid     lipgloss.Style
 
 	// Preview
 	PreviewTitle  lipgloss.Style
@@ -70,6 +81,24 @@ func DefaultStyles() Styles {
 
 		ProcessingItem: lipgloss.NewStyle().
 			Foreground(ui.ColorWarning),
+		QueuedItem: lipgloss.NewStyle().
+			Foreground(ui.ColorMuted),
+		ListSummary: lipgloss.NewStyle().
+			Foreground(ui.ColorMuted),
+
+		StatusOpen: lipgloss.NewStyle().
+			Foreground(ui.ColorText),
+		StatusAnalyzed: lipgloss.NewStyle().
+			Foreground(ui.ColorSecondary),
+		StatusPlanned: lipgloss.NewStyle().
+			Foreground(ui.ColorSuccess),
+		StatusImplemented: lipgloss.NewStyle().
+			Foreground(ui.ColorPrimary).
+			Bold(true),
+		StatusClosed: lipgloss.NewStyle().
+			Foreground(ui.ColorMuted),
+		StatusInvalid: lipgloss.NewStyle().
+			Foreground(ui.ColorError),
 
 		PreviewTitle: lipgloss.NewStyle().
 			Bold(true).
@@ -95,6 +124,26 @@ func DefaultStyles() Styles {
 	}
 }
 
+// StatusStyle returns the list row style for an issue status
+func (s Styles) StatusStyle(status model.IssueStatus) lipgloss.Style {
+	switch status {
+	case model.StatusOpen:
+		return s.StatusOpen
+	case model.StatusAnalyzed:
+		return s.StatusAnalyzed
+	case model.StatusPlanned:
+		return s.StatusPlanned
+	case model.StatusImplemented:
+		return s.StatusImplemented
+	case model.StatusClosed:
+		return s.StatusClosed
+	case model.StatusInvalid:
+		return s.StatusInvalid
+	default:
+		return s.NormalItem
+	}
+}
+
 // OverlayIcons defines icons used in overlay popups
 var OverlayIcons = struct {
 	Confirm string
@@ -143,6 +192,21 @@ var OverlayStyles = struct {
 		Foreground(ui.ColorBorder),
 }
 
+// DiffStyles colors the lines of a unified diff
+var DiffStyles = struct {
+	Header  lipgloss.Style
+	Hunk    lipgloss.Style
+	Added   lipgloss.Style
+	Removed lipgloss.Style
+	Context lipgloss.Style
+}{
+	Header:  lipgloss.NewStyle().Bold(true).Foreground(ui.ColorText),
+	Hunk:    lipgloss.NewStyle().Foreground(ui.ColorSecondary),
+	Added:   lipgloss.NewStyle().Foreground(ui.ColorSuccess),
+	Removed: lipgloss.NewStyle().Foreground(ui.ColorError),
+	Context: lipgloss.NewStyle().Foreground(ui.ColorText),
+}
+
 // OptionSelectStyles defines styles for option selection screen
 var OptionSelectStyles = struct {
 	// Panel styles
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// issueSummary is the subtitle shown under an issue in the list: the structured
+// analysis summary when there is one, otherwise the brief's first line of text
+func issueSummary(brief *model.Issue, analysis *model.Analysis) string {
+	if analysis != nil {
+		if summary := model.BriefSummary(analysis.Summary); summary != "" {
+			return summary
+		}
+	}
+	if brief == nil {
+		return ""
+	}
+	return model.BriefSummary(brief.Content)
+}
+
+// loadSummaries returns the list subtitles of issues, by issue ID
+func (m Model) loadSummaries(issues []*model.Issue) map[string]string {
+	summaries := make(map[string]string)
+	if m.config.UI.CompactList {
+		return summaries
+	}
+	for _, issue := range issues {
+		brief, _ := m.storage.LoadBrief(issue.ID)
+		analysis, _ := m.storage.LoadAnalysisJSON(issue.ID)
+		if summary := issueSummary(brief, analysis); summary != "" {
+			summaries[issue.ID] = summary
+		}
+	}
+	return summaries
+}
+
+// listRowHeight is the number of lines each issue takes in the list
+func (m Model) listRowHeight() int {
+	if m.config.UI.CompactList {
+		return 1
+	}
+	return 2
+}
+
+// listVisibleItems is the number of issues that fit in the list panel
+func (m Model) listVisibleItems() int {
+	return max(1, (m.height-3)/m.listRowHeight())
+}
@@ -0,0 +1,62 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/model"
+)
+
+// Template picker: after choosing a type, pick a named template from issues/.templates.
+// The first entry is the type's default.
+
+// templatePicker holds the available templates and the type chosen before them
+type templatePicker struct {
+	names     []string
+	cursor    int // 0 = type default, i = names[i-1]
+	issueType model.IssueType
+}
+
+func (m Model) handleTemplateSelectKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
+		m.state = StateTypeSelect
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.templates.cursor > 0 {
+			m.templates.cursor--
+		}
+	case "down", "j":
+		if m.templates.cursor < len(m.templates.names) {
+			m.templates.cursor++
+		}
+	case "enter":
+		name := ""
+		if m.templates.cursor > 0 {
+			name = m.templates.names[m.templates.cursor-1]
+		}
+		return m.createIssueFromTemplate(m.templates.issueType, name)
+	}
+	return m, nil
+}
+
+func (m Model) renderTemplateSelectOverlay() string {
+	options := append([]string{fmt.Sprintf("Default (%s)", m.templates.issueType)}, m.templates.names...)
+
+	var lines []string
+	for i, option := range options {
+		if i == m.templates.cursor {
+			lines = append(lines, OverlayStyles.Selected.Render("▸ "+option))
+		} else {
+			lines = append(lines, "  "+option)
+		}
+	}
+
+	footer := "[Enter] Create    [Esc] Back    ↑↓ Select"
+	return m.renderBaseOverlay("Select Template", strings.Join(lines, "\n"), footer, 50)
+}
@@ -0,0 +1,160 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+)
+
+// Version diff: what a review changed, as a line diff between an analysis or plan
+// version and the one before it. Entered with [d] from the review previews (the
+// current version) or from the version list (the selected version).
+
+// versionDiffState holds the compared versions and the state to return to
+type versionDiffState struct {
+	doc      string // "analysis" or "plan"
+	from, to int
+	added    int
+	removed  int
+	returnTo AppState
+}
+
+// openVersionDiff diffs version of doc against its predecessor; version 0 means the
+// version currently in analysis.md or plan.md
+func (m Model) openVersionDiff(doc string, version int) (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	list, load := m.storage.ListAnalysisVersions, m.storage.LoadAnalysisVersion
+	if doc == "plan" {
+		list, load = m.storage.ListPlanVersions, m.storage.LoadPlanVersion
+	}
+	versions, err := list(issue.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to list versions: %v", err)
+		return m, nil
+	}
+	if version == 0 {
+		if doc == "plan" {
+			version = m.storage.GetPlanVersion(issue.ID)
+		} else {
+			version = m.storage.GetAnalysisVersion(issue.ID)
+		}
+	}
+	i := slices.Index(versions, version)
+	switch {
+	case i < 0:
+		m.statusMsg = fmt.Sprintf("No %s versions to compare (created by review feedback)", doc)
+		return m, nil
+	case i == 0:
+		m.statusMsg = fmt.Sprintf("v%d is the first %s version: no previous version to compare", version, doc)
+		return m, nil
+	}
+
+	from, to := versions[i-1], version
+	oldContent, err := load(issue.ID, from)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to load v%d: %v", from, err)
+		return m, nil
+	}
+	newContent, err := load(issue.ID, to)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to load v%d: %v", to, err)
+		return m, nil
+	}
+
+	m.versionDiff = versionDiffState{doc: doc, from: from, to: to, returnTo: m.state}
+	var lines []string
+	for _, line := range storage.DiffLines(oldContent, newContent) {
+		switch line.Op {
+		case storage.DiffAdded:
+			lines = append(lines, "+ "+line.Text)
+			m.versionDiff.added++
+		case storage.DiffRemoved:
+			lines = append(lines, "- "+line.Text)
+			m.versionDiff.removed++
+		default:
+			lines = append(lines, "  "+line.Text)
+		}
+	}
+
+	m.sizeOverlayViewport()
+	m.viewport.SetContent(colorizeDiff(strings.Join(lines, "\n"), m.viewport.Width))
+	m.viewport.GotoTop()
+	m.state = StateVersionDiff
+	return m, nil
+}
+
+// closeVersionDiff returns to the version list or reloads the preview the diff was
+// opened from, since the diff borrowed its viewport
+func (m Model) closeVersionDiff() (Model, tea.Cmd) {
+	returnTo := m.versionDiff.returnTo
+	m.versionDiff = versionDiffState{}
+	switch returnTo {
+	case StateVersions:
+		m.state = StateVersions
+		return m, nil
+	case StatePlanPreview:
+		m.state = StateNormal
+		return m.planReviewIssue()
+	case StateReviewPreview:
+		m.state = StateNormal
+		return m.reviewIssue()
+	}
+	m.state = StateNormal
+	return m, nil
+}
+
+func (m Model) handleVersionDiffKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if key.Matches(msg, m.keys.Escape) {
+		return m.closeVersionDiff()
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		m.viewport.LineUp(1)
+	case "down", "j":
+		m.viewport.LineDown(1)
+	case "pgup", "ctrl+u":
+		m.viewport.HalfViewUp()
+	case "pgdown", "ctrl+d":
+		m.viewport.HalfViewDown()
+	case "home", "g":
+		m.viewport.GotoTop()
+	case "end", "G":
+		m.viewport.GotoBottom()
+	case "d", "q":
+		return m.closeVersionDiff()
+	}
+	return m, nil
+}
+
+func (m Model) renderVersionDiffOverlay() string {
+	popupWidth := min(max(m.width-10, 60), 100)
+
+	doc := "Analysis"
+	if m.versionDiff.doc == "plan" {
+		doc = "Plan"
+	}
+	title := fmt.Sprintf("%s v%d → v%d", doc, m.versionDiff.from, m.versionDiff.to)
+	if issue := m.getSelectedIssue(); issue != nil {
+		title = fmt.Sprintf("%s [%s]", title, issue.ID)
+	}
+
+	scrollInfo := fmt.Sprintf(" %3.0f%% ", m.viewport.ScrollPercent()*100)
+	separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
+	content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, OverlayStyles.Hint.Render(scrollInfo))
+
+	footer := fmt.Sprintf("%s %s    [Esc] Back    ↑↓ Scroll",
+		DiffStyles.Added.Render(fmt.Sprintf("+%d", m.versionDiff.added)),
+		DiffStyles.Removed.Render(fmt.Sprintf("-%d", m.versionDiff.removed)))
+	return m.renderBaseOverlay(title, content, footer, popupWidth)
+}
@@ -0,0 +1,349 @@
+package tui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+
+	"github.com/lunit-heesungyang/issue-manager/internal/storage"
+	"github.com/lunit-heesungyang/issue-manager/internal/ui"
+)
+
+// Version picker: browse analysis_vN.md or plan_vN.md history and restore one as the
+// current document. Entered with [V] from the analysis or plan review preview; for
+// analyses [b] shows a blame view annotating each line of the current analysis with
+// the version that introduced it.
+
+// versionState holds the version list and whether a version is open in the viewport
+type versionState struct {
+	doc      string // "analysis" or "plan"
+	versions []int  // ascending
+	current  int    // version currently in analysis.md or plan.md
+	cursor   int
+	viewing  bool
+	blame    *storage.AnalysisBlame // non-nil while the blame view is open
+}
+
+// blameColors tints each version's lines; versions cycle through the palette
+var blameColors = []lipgloss.Color{"109", "144", "139", "108", "180", "110", "174", "151"}
+
+// selected returns the version under the cursor
+func (v versionState) selected() int {
+	if v.cursor < 0 || v.cursor >= len(v.versions) {
+		return 0
+	}
+	return v.versions[v.cursor]
+}
+
+// openVersions lists the selected issue's analysis or plan versions, newest first
+// under the cursor
+func (m Model) openVersions(doc string) (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		m.statusMsg = "No issue selected"
+		return m, nil
+	}
+
+	var versions []int
+	var current int
+	var err error
+	if doc == "plan" {
+		versions, err = m.storage.ListPlanVersions(issue.ID)
+		current = m.storage.GetPlanVersion(issue.ID)
+	} else {
+		versions, err = m.storage.ListAnalysisVersions(issue.ID)
+		current = m.storage.GetAnalysisVersion(issue.ID)
+	}
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to list versions: %v", err)
+		return m, nil
+	}
+	if len(versions) == 0 {
+		if doc == "plan" {
+			m.statusMsg = "No previous plan versions (created by re-planning or plan feedback)"
+		} else {
+			m.statusMsg = "No previous analysis versions (created by review feedback)"
+		}
+		return m, nil
+	}
+
+	m.versions = versionState{
+		doc:      doc,
+		versions: versions,
+		current:  current,
+		cursor:   len(versions) - 1,
+	}
+	m.state = StateVersions
+	return m, nil
+}
+
+// viewVersion loads the version under the cursor into the viewport
+func (m Model) viewVersion() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		return m, nil
+	}
+
+	load := m.storage.LoadAnalysisVersion
+	if m.versions.doc == "plan" {
+		load = m.storage.LoadPlanVersion
+	}
+	content, err := load(issue.ID, m.versions.selected())
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Failed to load v%d: %v", m.versions.selected(), err)
+		return m, nil
+	}
+
+	m.sizeOverlayViewport()
+	m.viewport.SetContent(wrapText(content, m.viewport.Width))
+	m.viewport.GotoTop()
+	m.versions.viewing = true
+	return m, nil
+}
+
+// openBlame annotates the current analysis with the version that added each line
+func (m Model) openBlame() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil || m.versions.doc == "plan" {
+		return m, nil
+	}
+
+	blame, err := m.storage.BlameAnalysis(issue.ID)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Blame failed: %v", err)
+		return m, nil
+	}
+
+	m.sizeOverlayViewport()
+	m.viewport.SetContent(renderBlame(blame, m.viewport.Width))
+	m.viewport.GotoTop()
+	m.versions.blame = blame
+	return m, nil
+}
+
+// renderBlame prefixes each line with a colored version gutter, wrapping long lines
+// under a blank gutter
+func renderBlame(blame *storage.AnalysisBlame, width int) string {
+	const gutterWidth = 6
+	textWidth := max(10, width-gutterWidth)
+
+	var sb strings.Builder
+	for _, line := range blame.Lines {
+		label := "edit"
+		style := lipgloss.NewStyle().Foreground(ui.ColorMuted)
+		if line.Version > 0 {
+			label = fmt.Sprintf("v%d", line.Version)
+			style = lipgloss.NewStyle().Foreground(blameColors[(line.Version-1)%len(blameColors)])
+		}
+
+		for i, part := range strings.Split(wrapText(line.Text, textWidth), "\n") {
+			gutter := strings.Repeat(" ", gutterWidth-2) + "│ "
+			if i == 0 {
+				gutter = runewidth.FillRight(label, gutterWidth-2) + "│ "
+			}
+			sb.WriteString(style.Render(gutter + part))
+			sb.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// blameRemovedSummary lists how many lines each version removed, e.g. "removed: v3 -2"
+func blameRemovedSummary(blame *storage.AnalysisBlame) string {
+	if len(blame.Removed) == 0 {
+		return "no lines removed"
+	}
+
+	versions := make([]int, 0, len(blame.Removed))
+	for v := range blame.Removed {
+		versions = append(versions, v)
+	}
+	sort.Ints(versions)
+
+	parts := make([]string, 0, len(versions))
+	for _, v := range versions {
+		label := fmt.Sprintf("v%d", v)
+		if v == 0 {
+			label = "edit"
+		}
+		parts = append(parts, fmt.Sprintf("%s -%d", label, blame.Removed[v]))
+	}
+	return "removed: " + strings.Join(parts, ", ")
+}
+
+// confirmRestoreVersion asks before overwriting analysis.md or plan.md with the
+// selected version
+func (m Model) confirmRestoreVersion() (Model, tea.Cmd) {
+	issue := m.getSelectedIssue()
+	if issue == nil {
+		return m, nil
+	}
+	doc := m.versions.doc
+	version := m.versions.selected()
+	if version == m.versions.current {
+		m.statusMsg = fmt.Sprintf("v%d is already the current %s", version, doc)
+		return m, nil
+	}
+
+	restore := m.storage.RestoreAnalysisVersion
+	if doc == "plan" {
+		restore = m.storage.RestorePlanVersion
+	}
+	m.state = StateConfirm
+	m.confirmMsg = fmt.Sprintf("Restore %s v%d for %s? (current v%d is kept in history)", doc, version, issue.ID, m.versions.current)
+	m.confirmCmd = func() tea.Msg {
+		newVersion, err := restore(issue.ID, version)
+		if err != nil {
+			return actionDoneMsg{status: fmt.Sprintf("Restore failed: %v", err)}
+		}
+		return actionDoneMsg{status: fmt.Sprintf("Restored v%d as v%d for %s", version, newVersion, issue.ID)}
+	}
+	return m, nil
+}
+
+// closeVersions returns to the review preview it was opened from, reloading the document
+func (m Model) closeVersions() (Model, tea.Cmd) {
+	doc := m.versions.doc
+	m.versions = versionState{}
+	m.state = StateNormal
+	if doc == "plan" {
+		return m.planReviewIssue()
+	}
+	return m.reviewIssue()
+}
+
+func (m Model) handleVersionsKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	if m.versions.blame != nil {
+		if key.Matches(msg, m.keys.Escape) {
+			m.versions.blame = nil
+			return m, nil
+		}
+		switch msg.String() {
+		case "q", "b":
+			m.versions.blame = nil
+		case "up", "k":
+			m.viewport.LineUp(1)
+		case "down", "j":
+			m.viewport.LineDown(1)
+		case "pgup", "ctrl+u":
+			m.viewport.HalfViewUp()
+		case "pgdown", "ctrl+d":
+			m.viewport.HalfViewDown()
+		case "home", "g":
+			m.viewport.GotoTop()
+		case "end", "G":
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+	}
+
+	if m.versions.viewing {
+		if key.Matches(msg, m.keys.Escape) {
+			m.versions.viewing = false
+			return m, nil
+		}
+		switch msg.String() {
+		case "q":
+			m.versions.viewing = false
+		case "r":
+			return m.confirmRestoreVersion()
+		case "up", "k":
+			m.viewport.LineUp(1)
+		case "down", "j":
+			m.viewport.LineDown(1)
+		case "pgup", "ctrl+u":
+			m.viewport.HalfViewUp()
+		case "pgdown", "ctrl+d":
+			m.viewport.HalfViewDown()
+		case "home", "g":
+			m.viewport.GotoTop()
+		case "end", "G":
+			m.viewport.GotoBottom()
+		}
+		return m, nil
+	}
+
+	if key.Matches(msg, m.keys.Escape) {
+		return m.closeVersions()
+	}
+
+	switch msg.String() {
+	case "up", "k":
+		if m.versions.cursor > 0 {
+			m.versions.cursor--
+		}
+	case "down", "j":
+		if m.versions.cursor < len(m.versions.versions)-1 {
+			m.versions.cursor++
+		}
+	case "enter":
+		return m.viewVersion()
+	case "r":
+		return m.confirmRestoreVersion()
+	case "b":
+		return m.openBlame()
+	case "d":
+		return m.openVersionDiff(m.versions.doc, m.versions.selected())
+	case "V", "q":
+		return m.closeVersions()
+	}
+	return m, nil
+}
+
+func (m Model) renderVersionsOverlay() string {
+	popupWidth := m.width - 10
+	if popupWidth < 60 {
+		popupWidth = 60
+	}
+	if popupWidth > 100 {
+		popupWidth = 100
+	}
+
+	title := "Analysis Versions"
+	if m.versions.doc == "plan" {
+		title = "Plan Versions"
+	}
+	if issue := m.getSelectedIssue(); issue != nil {
+		title = fmt.Sprintf("%s [%s]", title, issue.ID)
+	}
+
+	if m.versions.blame != nil {
+		scrollInfo := fmt.Sprintf(" %3.0f%% ", m.viewport.ScrollPercent()*100)
+		separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
+		content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, OverlayStyles.Hint.Render(scrollInfo))
+		footer := fmt.Sprintf("%s    [Esc] Back to list    ↑↓ Scroll", blameRemovedSummary(m.versions.blame))
+		return m.renderBaseOverlay(title+" - Blame", content, footer, popupWidth)
+	}
+
+	if m.versions.viewing {
+		scrollInfo := fmt.Sprintf(" %3.0f%% ", m.viewport.ScrollPercent()*100)
+		separator := OverlayStyles.Separator.Render(strings.Repeat("─", popupWidth-10))
+		content := fmt.Sprintf("%s\n%s%s", m.viewport.View(), separator, OverlayStyles.Hint.Render(scrollInfo))
+		footer := "[r] Restore    [Esc] Back to list    ↑↓ Scroll"
+		return m.renderBaseOverlay(fmt.Sprintf("%s v%d", title, m.versions.selected()), content, footer, popupWidth)
+	}
+
+	var lines []string
+	for i, v := range m.versions.versions {
+		label := fmt.Sprintf("v%d", v)
+		if v == m.versions.current {
+			label += "  (current)"
+		}
+		if i == m.versions.cursor {
+			lines = append(lines, OverlayStyles.Selected.Render("▸ "+label))
+		} else {
+			lines = append(lines, "  "+label)
+		}
+	}
+
+	footer := "[Enter] View    [d] Diff    [r] Restore    [b] Blame    [Esc] Back    ↑↓ Select"
+	if m.versions.doc == "plan" {
+		footer = "[Enter] View    [d] Diff    [r] Restore    [Esc] Back    ↑↓ Select"
+	}
+	return m.renderBaseOverlay(title, strings.Join(lines, "\n"), footer, popupWidth)
+}
@@ -0,0 +1,72 @@
+package ui
+
+import (
+	"os"
+	"strings"
+)
+
+// asciiReplacer maps every non-ASCII glyph the TUI draws (icons, borders, spinner,
+// arrows) to an ASCII stand-in of the same display width, so layouts computed for
+// the Unicode glyphs still line up. Multi-rune sequences come first so they win.
+var asciiReplacer = strings.NewReplacer(
+	// Icons (emoji are two cells wide)
+	IconConfirm, "! ",
+	IconTypeFeature, "F ",
+	IconTypeBug, "B ",
+	IconTypeRefactor, "R ",
+	IconTypeUnknown, "? ",
+	IconCommit, "C ",
+	"⚠", "!",
+	"○", "o",
+	"◐", "a",
+	"●", "p",
+	"◉", "*",
+	"✓", "v",
+	"✗", "x",
+	"✎", ">",
+	"★", "*",
+	"☑", "+",
+	IconMarked, "#",
+	IconQueued, ".",
+	IconBlocked, "b",
+	IconPriorityCritical, "!",
+	IconPriorityHigh, "^",
+	IconPriorityLow, "v",
+	// Spinner
+	"⠋", "|", "⠙", "/", "⠹", "-", "⠸", "\\", "⠼", "|",
+	"⠴", "/", "⠦", "-", "⠧", "\\", "⠇", "|", "⠏", "/",
+	// Box drawing (lipgloss normal and rounded borders, separators)
+	"─", "-", "│", "|",
+	"╭", "+", "╮", "+", "╰", "+", "╯", "+",
+	"┌", "+", "┐", "+", "└", "+", "┘", "+",
+	"├", "+", "┤", "+", "┬", "+", "┴", "+", "┼", "+",
+	// Markers and punctuation
+	"▸", ">", "▰", "#", "▱", ".", "█", "_",
+	"…", ".", "·", "-",
+	"↑", "^", "↓", "v", "←", "<", "→", ">", "↔", "=", "↵", "<",
+)
+
+// ToASCII replaces the TUI's Unicode glyphs with ASCII equivalents
+func ToASCII(s string) string {
+	return asciiReplacer.Replace(s)
+}
+
+// DetectASCII guesses whether the terminal can't render Unicode: a known
+// limited TERM, or a locale that isn't UTF-8
+func DetectASCII() bool {
+	switch os.Getenv("TERM") {
+	case "dumb", "linux", "vt100", "vt102", "vt220", "ansi":
+		return true
+	}
+
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		locale := os.Getenv(name)
+		if locale == "" {
+			continue
+		}
+		locale = strings.ToLower(locale)
+		return !strings.Contains(locale, "utf-8") && !strings.Contains(locale, "utf8")
+	}
+	// No locale set: assume a modern terminal
+	return false
+}
@@ -11,6 +11,13 @@ const (
 	IconStatusUnknown     = "?"
 )
 
+// Priority markers shown before the title; medium has none
+const (
+	IconPriorityCritical = "▲"
+	IconPriorityHigh     = "△"
+	IconPriorityLow      = "▽"
+)
+
 // Type icons for each issue type
 const (
 	IconTypeFeature  = "💡"
@@ -25,6 +32,9 @@ const (
 	IconSuccess = "✓"
 	IconInput   = "✎"
 	IconCommit  = "📝"
+	IconMarked  = "◆"
+	IconQueued  = "◌" // a Claude task waiting for a free slot
+	IconBlocked = "⊘" // an issue blocked by another that isn't closed
 )
 
 // Checkbox icons